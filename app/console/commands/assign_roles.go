@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/rbac"
+)
+
+type AssignRoles struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *AssignRoles) Signature() string {
+	return "user:roles"
+}
+
+// Description The console command description.
+func (receiver *AssignRoles) Description() string {
+	return "Replace the roles of a user, e.g. user:roles user@example.com admin"
+}
+
+// Extend The console command extend.
+func (receiver *AssignRoles) Extend() command.Extend {
+	return command.Extend{}
+}
+
+// Handle Execute the console command.
+//
+// Used to appoint the first admin, who can then manage roles over the API.
+func (receiver *AssignRoles) Handle(ctx console.Context) error {
+	email := ctx.Argument(0)
+	if email == "" {
+		return fmt.Errorf("the email of the user is required")
+	}
+	roles := ctx.Arguments()[1:]
+
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", email).FirstOrFail(&user); err != nil {
+		return fmt.Errorf("user %s not found", email)
+	}
+
+	if err := rbac.Sync(user.ID, roles); err != nil {
+		return err
+	}
+
+	facades.Log().Infof("Roles of user %d were set to %v", user.ID, roles)
+	ctx.Info(fmt.Sprintf("Roles of %s have been set to %v", email, roles))
+	return nil
+}
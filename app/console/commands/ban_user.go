@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/users"
+)
+
+type BanUser struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *BanUser) Signature() string {
+	return "user:ban"
+}
+
+// Description The console command description.
+func (receiver *BanUser) Description() string {
+	return "Ban a user, or lift the ban with --lift, e.g. user:ban user@example.com --reason=spam"
+}
+
+// Extend The console command extend.
+func (receiver *BanUser) Extend() command.Extend {
+	return command.Extend{
+		Flags: []command.Flag{
+			&command.StringFlag{
+				Name:  "reason",
+				Usage: "Reason of the ban, kept for the admins",
+			},
+			&command.BoolFlag{
+				Name:  "lift",
+				Usage: "Lift the ban instead",
+			},
+		},
+	}
+}
+
+// Handle Execute the console command.
+//
+// The sessions of a banned user are revoked, their access tokens are rejected
+// by the auth middleware once the cached user expires on every instance.
+func (receiver *BanUser) Handle(ctx console.Context) error {
+	email := ctx.Argument(0)
+	if email == "" {
+		return fmt.Errorf("the email of the user is required")
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", email).FirstOrFail(&user); err != nil {
+		return fmt.Errorf("user %s not found", email)
+	}
+
+	if ctx.OptionBool("lift") {
+		if err := users.Unban(user); err != nil {
+			return err
+		}
+
+		facades.Log().Infof("Ban of user %d was lifted", user.ID)
+		ctx.Info(fmt.Sprintf("The ban of %s has been lifted", email))
+		return nil
+	}
+
+	if err := users.Ban(user, ctx.Option("reason")); err != nil {
+		return err
+	}
+
+	facades.Log().Infof("User %d was banned", user.ID)
+	ctx.Info(fmt.Sprintf("%s has been banned", email))
+	return nil
+}
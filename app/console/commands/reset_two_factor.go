@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/twofactor"
+)
+
+type ResetTwoFactor struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *ResetTwoFactor) Signature() string {
+	return "user:reset-two-factor"
+}
+
+// Description The console command description.
+func (receiver *ResetTwoFactor) Description() string {
+	return "Turn off two-factor authentication of a user who lost their device, e.g. user:reset-two-factor user@example.com"
+}
+
+// Extend The console command extend.
+func (receiver *ResetTwoFactor) Extend() command.Extend {
+	return command.Extend{}
+}
+
+// Handle Execute the console command.
+//
+// The user's sessions are revoked as well, so the account has to be signed in
+// again with the password and can enroll a new device.
+func (receiver *ResetTwoFactor) Handle(ctx console.Context) error {
+	email := ctx.Argument(0)
+	if email == "" {
+		return fmt.Errorf("the email of the user is required")
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", email).FirstOrFail(&user); err != nil {
+		return fmt.Errorf("user %s not found", email)
+	}
+
+	if err := twofactor.Disable(user.ID); err != nil {
+		return err
+	}
+	if err := tokens.RevokeUser(user.ID); err != nil {
+		return err
+	}
+
+	facades.Log().Infof("Two-factor authentication of user %d was reset", user.ID)
+	ctx.Info(fmt.Sprintf("Two-factor authentication of %s has been reset", email))
+	return nil
+}
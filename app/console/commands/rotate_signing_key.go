@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/console"
+	"github.com/goravel/framework/contracts/console/command"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/services/signing"
+)
+
+type RotateSigningKey struct {
+}
+
+// Signature The name and signature of the console command.
+func (receiver *RotateSigningKey) Signature() string {
+	return "jwt:rotate"
+}
+
+// Description The console command description.
+func (receiver *RotateSigningKey) Description() string {
+	return "Sign access tokens with a new key, tokens signed with the previous keys stay valid until they expire"
+}
+
+// Extend The console command extend.
+func (receiver *RotateSigningKey) Extend() command.Extend {
+	return command.Extend{}
+}
+
+// Handle Execute the console command.
+func (receiver *RotateSigningKey) Handle(ctx console.Context) error {
+	key, err := signing.Rotate()
+	if err != nil {
+		return err
+	}
+
+	facades.Log().Infof("Access tokens are signed with key %s now", key.Kid)
+	ctx.Info(fmt.Sprintf("Access tokens are signed with the %s key %s now", key.Algorithm, key.Kid))
+	return nil
+}
@@ -15,12 +15,18 @@ func (kernel *Kernel) Commands() []console.Command {
 	return []console.Command{
 		&commands.EndPoll{},
 		&commands.StartPoll{},
+		&commands.ResetTwoFactor{},
+		&commands.AssignRoles{},
+		&commands.RotateSigningKey{},
+		&commands.BanUser{},
 	}
 }
 
+// Schedule Polls are transitioned at their exact dates by the lifecycle
+// scheduler, the commands only sweep up transitions it missed.
 func (kernel *Kernel) Schedule() []schedule.Event {
 	return []schedule.Event{
-		facades.Schedule().Command("poll:end").EveryMinute(),
-		facades.Schedule().Command("poll:start").EveryMinute(),
+		facades.Schedule().Command("poll:start").EveryFiveMinutes().SkipIfStillRunning(),
+		facades.Schedule().Command("poll:end").EveryFiveMinutes().SkipIfStillRunning(),
 	}
 }
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// AccountLocked is fired after too many failed logins locked an account.
+//
+// Args: user_id uint, ip_address string, duration int (minutes)
+type AccountLocked struct {
+}
+
+func (receiver *AccountLocked) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
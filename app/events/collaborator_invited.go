@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// CollaboratorInvited is fired after a user is invited to collaborate on a poll.
+//
+// Args: poll_id uint, email string, invitation_token string, role string, inviter_name string
+type CollaboratorInvited struct {
+}
+
+func (receiver *CollaboratorInvited) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
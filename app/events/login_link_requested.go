@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// LoginLinkRequested is fired after a passwordless login is requested for a user.
+//
+// Args: user_id uint, email string, login_token string, code string
+type LoginLinkRequested struct {
+}
+
+func (receiver *LoginLinkRequested) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// OptionChanged is fired when an option of a poll is created, updated or deleted.
+//
+// Args: poll_id uint, option_id uint, action string (created, updated, deleted)
+type OptionChanged struct {
+}
+
+func (receiver *OptionChanged) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PasswordResetRequested is fired after a reset token is issued for a user.
+//
+// Args: user_id uint, email string, reset_token string
+type PasswordResetRequested struct {
+}
+
+func (receiver *PasswordResetRequested) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollCreated is fired after a poll is created.
+//
+// Args: poll_id uint
+type PollCreated struct {
+}
+
+func (receiver *PollCreated) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollDeleted is fired after a poll and its options are deleted.
+//
+// Args: poll_id uint
+type PollDeleted struct {
+}
+
+func (receiver *PollDeleted) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
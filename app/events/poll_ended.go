@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollEnded is fired when voting on a poll is closed.
+//
+// Args: poll_id uint
+type PollEnded struct {
+}
+
+func (receiver *PollEnded) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollStarted is fired when a poll becomes active.
+//
+// Args: poll_id uint
+type PollStarted struct {
+}
+
+func (receiver *PollStarted) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollStatusChanged is fired after every transition of the poll state machine.
+//
+// Args: poll_id uint, from string, to string, reason string
+type PollStatusChanged struct {
+}
+
+func (receiver *PollStatusChanged) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
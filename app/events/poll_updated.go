@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// PollUpdated is fired after the details or dates of a poll are changed.
+//
+// Args: poll_id uint
+type PollUpdated struct {
+}
+
+func (receiver *PollUpdated) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
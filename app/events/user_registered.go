@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// UserRegistered is fired after a new account is created.
+//
+// Args: user_id uint, email string, verification_token string
+type UserRegistered struct {
+}
+
+func (receiver *UserRegistered) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,14 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// VerificationRequested is fired after a new verification token is issued for
+// an unverified user.
+//
+// Args: user_id uint, email string, verification_token string
+type VerificationRequested struct {
+}
+
+func (receiver *VerificationRequested) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// VoteCast is fired after a ballot is recorded.
+//
+// Args: poll_id uint, option_id uint, vote_id uint
+type VoteCast struct {
+}
+
+func (receiver *VoteCast) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
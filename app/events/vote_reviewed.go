@@ -0,0 +1,13 @@
+package events
+
+import "github.com/goravel/framework/contracts/event"
+
+// VoteReviewed is fired after the owner of a poll approved or quarantined a vote.
+//
+// Args: poll_id uint, option_id uint, vote_id uint, status string (approved, quarantined)
+type VoteReviewed struct {
+}
+
+func (receiver *VoteReviewed) Handle(args []event.Arg) ([]event.Arg, error) {
+	return args, nil
+}
@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"errors"
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/apikeys"
+	"evote-be/app/services/users"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type ApiKeyController struct {
+	// Dependent services
+}
+
+func NewApiKeyController() *ApiKeyController {
+	return &ApiKeyController{
+		// Inject services
+	}
+}
+
+// Index Get the API keys of the user
+// @Summary Get API keys
+// @Description Get the API keys of the user, newest first, including expired and revoked ones
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.ApiKeyResponse] "API keys found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/api-keys [get]
+func (r *ApiKeyController) Index(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	var keys []models.ApiKeys
+	if err := facades.Orm().Query().Where("user_id = ?", user.ID).OrderBy("id", "desc").Find(&keys); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get API keys",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.ApiKeyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = key.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.ApiKeyResponse]{
+		Message: "API keys found",
+		Data:    resp,
+	})
+}
+
+// Store Create an API key
+// @Summary Create an API key
+// @Description Create a scoped API key for scripts and third-party integrations. The key
+// @Description is sent like an access token ("Authorization: Bearer evk_...") and is only
+// @Description returned once. Endpoints list the scopes they need, account endpoints
+// @Description cannot be used with API keys.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.CreateApiKey true "API key data"
+// @Success 201 {object} models.ResponseWithData[models.CreateApiKeyResponse] "API key created"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Too many API keys"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/api-keys/create [post]
+func (r *ApiKeyController) Store(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.CreateApiKey
+	validationErrors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if validationErrors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  validationErrors.All(),
+		})
+	}
+
+	expiresAt, err := apikeys.ExpiresAt(request.ExpiresIn, time.Now())
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  http.Json{"expires_in": err.Error()},
+		})
+	}
+
+	// Create API key
+	key, plain, err := apikeys.Create(user.ID, request.Name, request.Scopes, expiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikeys.ErrInvalidScope):
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  http.Json{"scopes": err.Error()},
+			})
+		case errors.Is(err, apikeys.ErrTooManyKeys):
+			return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+				Message: err.Error(),
+				Errors:  "TOO_MANY_API_KEYS",
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.CreateApiKeyResponse]{
+		Message: "API key created",
+		Data: models.CreateApiKeyResponse{
+			ApiKeyResponse: key.ToResponse(),
+			Key:            plain,
+		},
+	})
+}
+
+// Delete Revoke an API key
+// @Summary Revoke an API key
+// @Description Revoke an API key, requests with it are rejected from then on
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.ResponseWithMessage "API key revoked"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "API key not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/api-keys/{id}/delete [delete]
+func (r *ApiKeyController) Delete(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	if err := apikeys.Revoke(user.ID, ctx.Request().Route("id")); err != nil {
+		if errors.Is(err, apikeys.ErrNotFound) {
+			return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "API key not found",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to revoke API key",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "API key revoked successfully",
+	})
+}
@@ -2,12 +2,21 @@ package controllers
 
 import (
 	"errors"
+	"evote-be/app/events"
 	"evote-be/app/http/requests"
-	"evote-be/app/mails"
 	"evote-be/app/models"
-	"fmt"
+	"evote-be/app/services/lockout"
+	"evote-be/app/services/passwordless"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/twofactor"
+	"evote-be/app/services/users"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/goravel/framework/contracts/event"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -17,6 +26,23 @@ import (
 
 const UniqueViolation = "23505"
 
+// dummyHash is checked against when no user has the email, so that unknown
+// emails take as long to answer as wrong passwords
+var dummyHash = sync.OnceValue(func() string {
+	hash, _ := facades.Hash().Make("evote-dummy-password")
+	return hash
+})
+
+// checkPassword reports whether the password is the one of the user
+func checkPassword(user models.User, password string) bool {
+	if user.ID == 0 {
+		facades.Hash().Check(password, dummyHash())
+		return false
+	}
+
+	return facades.Hash().Check(password, user.Password)
+}
+
 type AuthController struct {
 	// Dependent services
 }
@@ -42,6 +68,7 @@ func NewAuthController() *AuthController {
 // @Success 	 201 {object} models.ResponseWithData[models.UserRegisterResponse] "Success response"
 // @Failure     400 {object} models.ErrorResponse "Validation error or email already taken"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
 // @Router      /auth/register [post]
 func (r *AuthController) Register(ctx http.Context) http.Response {
 	// Validate request data
@@ -69,13 +96,25 @@ func (r *AuthController) Register(ctx http.Context) http.Response {
 		})
 	}
 
+	// Generate verification token, only its hash is stored
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
 	// Create user
+	now := time.Now()
+	expiresAt := now.Add(verificationExpire())
 	user := models.User{
-		Name:              req.Name,
-		Email:             req.Email,
-		Password:          hashedPass,
-		VerificationToken: randomString(32),
-		TokenExpiresAt:    time.Now().Truncate(time.Minute).Add(time.Minute * 10).Format("2006-01-02 15:04"),
+		Name:                  req.Name,
+		Email:                 req.Email,
+		Password:              hashedPass,
+		VerificationToken:     tokenHash,
+		VerificationExpiresAt: &expiresAt,
+		VerificationSentAt:    &now,
 	}
 
 	// Save user
@@ -98,11 +137,12 @@ func (r *AuthController) Register(ctx http.Context) http.Response {
 		})
 	}
 
-	// Link for email verification
-	link := fmt.Sprintf("%s/auth/verify/%s", facades.Config().GetString("APP_URL", "http://localhost:3000"), user.VerificationToken)
-
-	// Send email
-	err = facades.Mail().Queue(mails.NewUserRegister(user.Email, link))
+	// Fire registered event, the verification email is sent by its listener
+	err = facades.Event().Job(&events.UserRegistered{}, []event.Arg{
+		{Type: "uint", Value: user.ID},
+		{Type: "string", Value: user.Email},
+		{Type: "string", Value: token},
+	}).Dispatch()
 	if err != nil {
 		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
 			Message: "ups, something went wrong",
@@ -126,16 +166,22 @@ func (r *AuthController) Register(ctx http.Context) http.Response {
 
 // @Summary     Login user
 //
-// @Description Login user with email and password
+// @Description Login user with email and password. Users with two-factor authentication
+// @Description get a challenge token instead, to be completed at /auth/two-factor/verify.
+// @Description After a failed login the email is refused for a growing delay given in the
+// @Description Retry-After header, too many failed logins lock the email or client for a while.
 //
 // @Tags        Auth
 // @Accept      json
 // @Produce     json
 // @Param       request body requests.UserLogin true "User Login Data"
 // @Success 	 200 {object} models.ResponseWithData[models.UserLoginResponse] "Success response"
+// @Success     200 {object} models.ResponseWithData[models.TwoFactorChallengeResponse] "Two-factor authentication required"
 // @Failure     400 {object} models.ErrorResponse "Validation error"
-// @Failure    401 {object} models.ErrorResponse "Unauthorized"
+// @Failure    401 {object} models.ErrorResponse "Invalid email or password"
+// @Failure     403 {object} models.ErrorResponse "Account banned"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests or too many failed logins"
 // @Router      /auth/login [post]
 func (r *AuthController) Login(ctx http.Context) http.Response {
 	// Validate request data
@@ -154,69 +200,271 @@ func (r *AuthController) Login(ctx http.Context) http.Response {
 		})
 	}
 
-	// Find user by email
+	// Refuse logins of a locked email or client
+	attempt := lockout.Attempt{
+		Email:     req.Email,
+		IPAddress: ctx.Request().Ip(),
+		UserAgent: ctx.Request().Header("User-Agent", ""),
+	}
+	if wait := lockout.LockedFor(attempt); wait > 0 {
+		if err := lockout.Record(attempt, false, models.LoginLockedOut); err != nil {
+			facades.Log().Errorf("Failed to record login attempt: %v", err)
+		}
+
+		retryAfter := int(math.Ceil(wait.Seconds()))
+		return ctx.Response().Header("Retry-After", strconv.Itoa(retryAfter)).Json(http.StatusTooManyRequests, models.ErrorResponse{
+			Message: "too many failed login attempts, please try again later",
+			Errors:  http.Json{"retry_after": retryAfter},
+		})
+	}
+
+	// Find user by email, unknown emails get the same answer as wrong passwords
 	var user models.User
-	if err := facades.Orm().Query().Where("email = ?", req.Email).FirstOrFail(&user); err != nil {
-		fmt.Print(err)
-		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+	if err := facades.Orm().Query().Where("email = ?", req.Email).First(&user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
 			Message: "ups, something went wrong",
-			Errors:  http.Json{"email": "email not found"},
+			Errors:  err.Error(),
 		})
 	}
+	if user.ID != 0 {
+		attempt.UserID = &user.ID
+	}
 
 	// Check password
-	if !facades.Hash().Check(req.Password, user.Password) {
-		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
-			Message: "ups, something went wrong",
-			Errors:  http.Json{"password": "password not match"},
+	if !checkPassword(user, req.Password) {
+		// The email is refused for the delay, clients learn when to retry
+		// instead of a request held open
+		delay, err := lockout.Fail(attempt)
+		if err != nil {
+			facades.Log().Errorf("Failed to record login attempt: %v", err)
+		}
+		response := ctx.Response()
+		if delay > 0 {
+			response = response.Header("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+		}
+
+		return response.Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "invalid email or password",
+			Errors:  http.Json{"credentials": "invalid email or password"},
 		})
 	}
 
 	// Check if user is verified
-	if user.EmailVerifiedAt == "" {
+	if user.EmailVerifiedAt == nil {
+		if err := lockout.Record(attempt, false, models.LoginEmailNotVerified); err != nil {
+			facades.Log().Errorf("Failed to record login attempt: %v", err)
+		}
+
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "please verify your email address",
 			Errors:  http.Json{"email": "email not verified"},
 		})
 	}
 
-	// Generate token
-	token, err := facades.Auth(ctx).LoginUsingID(user.ID)
+	// Banned accounts can no longer sign in
+	if user.BannedAt != nil {
+		if err := lockout.Record(attempt, false, models.LoginBanned); err != nil {
+			facades.Log().Errorf("Failed to record login attempt: %v", err)
+		}
+
+		return bannedResponse(ctx, user)
+	}
+
+	if err := lockout.Succeed(attempt); err != nil {
+		facades.Log().Errorf("Failed to record login attempt: %v", err)
+	}
+
+	return loginResponse(ctx, user)
+}
+
+// @Summary     Verify two-factor code
+// @Description Complete a login with the challenge token and a code of the authenticator
+// @Description app or a recovery code. A challenge is discarded after too many wrong codes.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.TwoFactorChallenge true "Two-Factor Challenge Data"
+// @Success     200 {object} models.ResponseWithData[models.UserLoginResponse] "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     401 {object} models.ErrorResponse "Invalid code or challenge"
+// @Failure     403 {object} models.ErrorResponse "Account banned"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/two-factor/verify [post]
+func (r *AuthController) VerifyTwoFactor(ctx http.Context) http.Response {
+	// Validate request data
+	var req requests.TwoFactorChallenge
+	allerror, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if allerror != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  allerror.All(),
+		})
+	}
+
+	// Check code for the challenge
+	userID, err := twofactor.CompleteChallenge(req.ChallengeToken, req.Code, req.RecoveryCode)
 	if err != nil {
+		if errors.Is(err, twofactor.ErrInvalidCode) || errors.Is(err, twofactor.ErrInvalidChallenge) {
+			return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  http.Json{"code": err.Error()},
+			})
+		}
+
 		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
 			Message: "ups, something went wrong",
 			Errors:  err.Error(),
 		})
 	}
 
-	// Return success response
-	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.UserLoginResponse]{
-		Message: "user logged in successfully",
-		Data: models.UserLoginResponse{
-			ID:     int(user.ID),
-			Name:   user.Name,
-			Email:  user.Email,
-			Avatar: user.Avatar,
-			Token:  token,
+	// Find user
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", userID).FirstOrFail(&user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if resp := bannedResponse(ctx, user); resp != nil {
+		return resp
+	}
+
+	return tokenResponse(ctx, user)
+}
+
+// @Summary     Refresh token
+// @Description Exchange a refresh token for a new access and refresh token. Each refresh
+// @Description token can only be used once; using it again revokes the whole session.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.RefreshToken true "Refresh Token Data"
+// @Success     200 {object} models.ResponseWithData[models.TokenResponse] "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     401 {object} models.ErrorResponse "Invalid, expired or reused refresh token"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/refresh [post]
+func (r *AuthController) Refresh(ctx http.Context) http.Response {
+	// Validate request data
+	var req requests.RefreshToken
+	allerror, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if allerror != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  allerror.All(),
+		})
+	}
+
+	// Rotate refresh token
+	pair, err := tokens.Rotate(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, tokens.ErrInvalidRefreshToken) || errors.Is(err, tokens.ErrRefreshTokenReused) {
+			return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+				Message: "please login again",
+				Errors:  http.Json{"refresh_token": err.Error()},
+			})
+		}
+
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.TokenResponse]{
+		Message: "token refreshed successfully",
+		Data: models.TokenResponse{
+			Token:        pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresAt:    pair.ExpiresAt,
 		},
 	})
 }
 
-// @Summary     Verify email
-// @Description Verify user email address and return an HTML page
+// @Summary     Logout user
+// @Description Revoke the access token and the refresh token of the current session
 // @Tags        Auth
 // @Accept      json
+// @Produce     json
+// @Security    Bearer
+// @Success     200 {object} models.ResponseWithMessage "Success response"
+// @Failure     401 {object} models.ErrorResponse "Unauthorized"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/logout [post]
+func (r *AuthController) Logout(ctx http.Context) http.Response {
+	// Get token from header, it was verified by the middleware
+	token := ctx.Request().Header("Authorization", "")
+	claims, err := tokens.Parse(ctx, token)
+	if err != nil {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Revoke session
+	if err := tokens.Logout(strings.TrimPrefix(token, "Bearer "), claims.ExpiresAt); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "user logged out successfully",
+	})
+}
+
+// @Summary     Confirm email verification
+// @Description Return an HTML page with a form that submits the verification, so
+// @Description that opening the link alone, e.g. by a mail scanner, verifies nothing
+// @Tags        Auth
 // @Produce     text/html
 // @Param       token path string true "Verification Token from email"
-// @Success     200 {string} string "HTML content (success or error message)"
+// @Success     200 {string} string "HTML content (verification form)"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
 // @Router      /auth/verify/{token} [get]
+func (r *AuthController) ConfirmVerify(ctx http.Context) http.Response {
+	return ctx.Response().View().Make("email-verify.tmpl", map[string]any{
+		"confirm":    true,
+		"csrf_token": ctx.Value("csrf_token"),
+	})
+}
+
+// @Summary     Verify email
+// @Description Verify user email address and return an HTML page. Submitted by the
+// @Description form of the confirmation page with the CSRF token in the "_token" field.
+// @Tags        Auth
+// @Accept      x-www-form-urlencoded
+// @Produce     text/html
+// @Param       token path string true "Verification Token from email"
+// @Param       _token formData string true "CSRF token of the confirmation page"
+// @Success     200 {string} string "HTML content (success or error message)"
+// @Failure     403 {object} models.ErrorResponse "CSRF token missing or invalid"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/verify/{token} [post]
 func (r *AuthController) Verify(ctx http.Context) http.Response {
 	// Get token from path
 	token := ctx.Request().Route("token")
 
-	// Find user by token
+	// Find user by token hash
 	var user models.User
-	if err := facades.Orm().Query().Where("verification_token", token).FirstOrFail(&user); err != nil {
+	if err := facades.Orm().Query().Where("verification_token = ?", tokens.Hash(token)).FirstOrFail(&user); err != nil {
 		return ctx.Response().View().Make("email-verify.tmpl", map[string]any{
 			"success": false,
 			"message": "The verification link has expired or is invalid. Please request a new one",
@@ -225,7 +473,7 @@ func (r *AuthController) Verify(ctx http.Context) http.Response {
 	}
 
 	// Check if user email already verified
-	if user.EmailVerifiedAt != "" {
+	if user.EmailVerifiedAt != nil {
 		return ctx.Response().View().Make("email-verify.tmpl", map[string]any{
 			"status":  false,
 			"message": "Your account has been already verified.",
@@ -233,22 +481,19 @@ func (r *AuthController) Verify(ctx http.Context) http.Response {
 
 	}
 
-	// Define time layout
-	layout := "2006-01-02 15:04"
-
 	// Check if token is expired
-	tokenExpiresAt, err := time.Parse(layout, user.TokenExpiresAt)
-	fmt.Println(err)
-	if err != nil || time.Now().After(tokenExpiresAt) {
+	if user.VerificationExpiresAt == nil || time.Now().After(*user.VerificationExpiresAt) {
 		return ctx.Response().View().Make("email-verify.tmpl", map[string]any{
 			"success": false,
 			"message": "The verification link has expired or is invalid. Please request a new one",
 		})
 	}
 
-	// Update user email verified at
-	now := time.Now().Truncate(time.Minute)
-	user.EmailVerifiedAt = now.Format(layout)
+	// Update user email verified at, the token cannot be used again
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.VerificationToken = ""
+	user.VerificationExpiresAt = nil
 
 	// Save user
 	if err := facades.Orm().Query().Save(&user); err != nil {
@@ -257,9 +502,502 @@ func (r *AuthController) Verify(ctx http.Context) http.Response {
 			"message": "Oops! Something went wrong. Please try again.",
 		})
 	}
+	users.Forget(user.ID)
 
 	return ctx.Response().View().Make("email-verify.tmpl", map[string]any{
 		"success": true,
 		"message": "Your account has been successfully verified. Welcome aboard! 🎉",
 	})
 }
+
+// @Summary     Resend verification email
+// @Description Send a new verification link to an unverified account, replacing the
+// @Description previous one. Only one email is sent per cooldown and the response is
+// @Description the same whether or not an unverified account exists for the email.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.ResendVerification true "Resend Verification Data"
+// @Success     200 {object} models.ResponseWithMessage "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/verify/resend [post]
+func (r *AuthController) ResendVerification(ctx http.Context) http.Response {
+	// Validate request data
+	var req requests.ResendVerification
+	errors, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  errors.All(),
+		})
+	}
+
+	response := models.ResponseWithMessage{
+		Message: "if the email belongs to an unverified account, a new verification link has been sent",
+	}
+
+	// Find user by email, unknown emails get the same response
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", req.Email).First(&user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if user.ID == 0 || user.EmailVerifiedAt != nil {
+		return ctx.Response().Json(http.StatusOK, response)
+	}
+
+	// Generate verification token, only its hash is stored
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Replace token unless one was sent within the cooldown, the condition keeps
+	// concurrent requests from sending more than one email
+	now := time.Now()
+	cooldown := time.Duration(facades.Config().GetInt("auth.verification.cooldown", 60)) * time.Second
+	result, err := facades.Orm().Query().Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", user.ID).
+		Where("verification_sent_at IS NULL OR verification_sent_at <= ?", now.Add(-cooldown)).
+		Update(map[string]any{
+			"verification_token":      tokenHash,
+			"verification_expires_at": now.Add(verificationExpire()),
+			"verification_sent_at":    now,
+		})
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if result.RowsAffected == 0 {
+		return ctx.Response().Json(http.StatusOK, response)
+	}
+
+	// Fire verification requested event, the email is sent by its listener
+	if err := facades.Event().Job(&events.VerificationRequested{}, []event.Arg{
+		{Type: "uint", Value: user.ID},
+		{Type: "string", Value: user.Email},
+		{Type: "string", Value: token},
+	}).Dispatch(); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, response)
+}
+
+// @Summary     Send login link
+// @Description Email a login link and a 6-digit code to the address, to login without the
+// @Description password. The response is the same whether or not the email belongs to an
+// @Description account. Limited per email and per client.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.PasswordlessSend true "Email"
+// @Success     200 {object} models.ResponseWithMessage "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     404 {object} models.ErrorResponse "Passwordless login disabled"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Router      /auth/passwordless/send [post]
+func (r *AuthController) SendLoginLink(ctx http.Context) http.Response {
+	if !facades.Config().GetBool("auth.passwordless.enabled") {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  "passwordless login is disabled",
+		})
+	}
+
+	// Validate request data
+	var req requests.PasswordlessSend
+	errors, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  errors.All(),
+		})
+	}
+
+	response := models.ResponseWithMessage{
+		Message: "if the email belongs to an account, a login link has been sent",
+	}
+
+	// Find user by email, unknown emails get the same response
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", req.Email).First(&user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if user.ID == 0 {
+		return ctx.Response().Json(http.StatusOK, response)
+	}
+
+	// Send link and code
+	if err := passwordless.Send(user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, response)
+}
+
+// @Summary     Login with link or code
+// @Description Login with the token of a login link, or with the email and the 6-digit
+// @Description code. Both are valid for a few minutes and once. Users with two-factor
+// @Description authentication get a challenge token instead.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.PasswordlessLogin true "Token, or email and code"
+// @Success     200 {object} models.ResponseWithData[models.UserLoginResponse] "Success response"
+// @Success     200 {object} models.ResponseWithData[models.TwoFactorChallengeResponse] "Two-factor authentication required"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     401 {object} models.ErrorResponse "Invalid or expired link or code"
+// @Failure     403 {object} models.ErrorResponse "Account banned"
+// @Failure     404 {object} models.ErrorResponse "Passwordless login disabled"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/passwordless/verify [post]
+func (r *AuthController) LoginWithLink(ctx http.Context) http.Response {
+	if !facades.Config().GetBool("auth.passwordless.enabled") {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  "passwordless login is disabled",
+		})
+	}
+
+	// Validate request data
+	var req requests.PasswordlessLogin
+	allerror, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if allerror != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  allerror.All(),
+		})
+	}
+
+	// Use link or code
+	var userID uint
+	if req.Token != "" {
+		userID, err = passwordless.LoginWithToken(req.Token)
+	} else {
+		userID, err = passwordless.LoginWithCode(req.Email, req.Code)
+	}
+	if err != nil {
+		if errors.Is(err, passwordless.ErrInvalidToken) {
+			return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", userID).FirstOrFail(&user); err != nil {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  passwordless.ErrInvalidToken.Error(),
+		})
+	}
+	if resp := bannedResponse(ctx, user); resp != nil {
+		return resp
+	}
+
+	return loginResponse(ctx, user)
+}
+
+// @Summary     Forgot password
+// @Description Send a password reset link to the email address. The response is the
+// @Description same whether or not an account exists for it.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.ForgotPassword true "Forgot Password Data"
+// @Success     200 {object} models.ResponseWithMessage "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/password/forgot [post]
+func (r *AuthController) ForgotPassword(ctx http.Context) http.Response {
+	// Validate request data
+	var req requests.ForgotPassword
+	errors, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  errors.All(),
+		})
+	}
+
+	response := models.ResponseWithMessage{
+		Message: "if the email is registered, a password reset link has been sent",
+	}
+
+	// Find user by email, unknown emails get the same response
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", req.Email).First(&user); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if user.ID == 0 {
+		return ctx.Response().Json(http.StatusOK, response)
+	}
+
+	// Generate token, only its hash is stored
+	token, hash, err := tokens.Generate()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Replace unused tokens of the user
+	if _, err := facades.Orm().Query().Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.PasswordResets{}); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	reset := models.PasswordResets{
+		UserID:    user.ID,
+		Token:     hash,
+		ExpiresAt: time.Now().Add(time.Duration(facades.Config().GetInt("auth.passwords.expire", 60)) * time.Minute),
+	}
+	if err := facades.Orm().Query().Create(&reset); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Fire reset requested event, the email is sent by its listener
+	if err := facades.Event().Job(&events.PasswordResetRequested{}, []event.Arg{
+		{Type: "uint", Value: user.ID},
+		{Type: "string", Value: user.Email},
+		{Type: "string", Value: token},
+	}).Dispatch(); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, response)
+}
+
+// @Summary     Reset password
+// @Description Set a new password with the token from the reset email. The token can
+// @Description only be used once and every token issued to the user before is rejected.
+// @Tags        Auth
+// @Accept      json
+// @Produce     json
+// @Param       request body requests.ResetPassword true "Reset Password Data"
+// @Success     200 {object} models.ResponseWithMessage "Success response"
+// @Failure     400 {object} models.ErrorResponse "Validation error or invalid token"
+// @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     429 {object} models.ErrorResponse "Too many requests"
+// @Router      /auth/password/reset [post]
+func (r *AuthController) ResetPassword(ctx http.Context) http.Response {
+	// Validate request data
+	var req requests.ResetPassword
+	errors, err := ctx.Request().ValidateRequest(&req)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, http.Json{
+			"message": "validation error",
+			"errors":  errors.All(),
+		})
+	}
+
+	invalidToken := models.ErrorResponse{
+		Message: "the reset link has expired or is invalid, please request a new one",
+		Errors:  http.Json{"token": "invalid token"},
+	}
+
+	// Find unused and unexpired token
+	var reset models.PasswordResets
+	if err := facades.Orm().Query().
+		Where("token = ? AND used_at IS NULL AND expires_at > ?", tokens.Hash(req.Token), time.Now()).
+		FirstOrFail(&reset); err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, invalidToken)
+	}
+
+	// Hash password
+	hashedPass, err := facades.Hash().Make(req.Password)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// JWTs only carry the issue time in seconds
+	now := time.Now().Truncate(time.Second)
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Mark token as used, the condition keeps it single-use when requests race
+	result, err := tx.Model(&models.PasswordResets{}).Where("id = ? AND used_at IS NULL", reset.ID).Update("used_at", now)
+	if err != nil {
+		tx.Rollback()
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return ctx.Response().Json(http.StatusBadRequest, invalidToken)
+	}
+
+	// Update password and reject every token issued before
+	if _, err := tx.Model(&models.User{}).Where("id = ?", reset.UserID).Update(map[string]any{
+		"password":           hashedPass,
+		"tokens_valid_after": now,
+	}); err != nil {
+		tx.Rollback()
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	tokens.Forget(reset.UserID)
+
+	// Sessions cannot be renewed with their refresh tokens either
+	if err := tokens.RevokeUser(reset.UserID); err != nil {
+		facades.Log().Errorf("Failed to revoke sessions of user %d: %v", reset.UserID, err)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "password has been reset, please login with your new password",
+	})
+}
+
+// verificationExpire returns how long a verification link stays valid
+func verificationExpire() time.Duration {
+	return time.Duration(facades.Config().GetInt("auth.verification.expire", 1440)) * time.Minute
+}
+
+// bannedResponse returns a forbidden response when the account is banned, or
+// nil when the user may sign in
+func bannedResponse(ctx http.Context, user models.User) http.Response {
+	if user.BannedAt == nil {
+		return nil
+	}
+
+	return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+		Message: "Your account has been banned",
+		Errors:  "ACCOUNT_BANNED",
+	})
+}
+
+// loginResponse signs the user in, users with two-factor authentication get a
+// challenge to continue with a code instead of tokens
+func loginResponse(ctx http.Context, user models.User) http.Response {
+	if user.TwoFactorConfirmedAt == nil {
+		return tokenResponse(ctx, user)
+	}
+
+	challenge, err := twofactor.NewChallenge(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.TwoFactorChallengeResponse]{
+		Message: "two-factor authentication required",
+		Data: models.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+		},
+	})
+}
+
+// tokenResponse issues the access and refresh token of a new session of the user
+func tokenResponse(ctx http.Context, user models.User) http.Response {
+	pair, err := tokens.Issue(ctx, user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.UserLoginResponse]{
+		Message: "user logged in successfully",
+		Data: models.UserLoginResponse{
+			ID:           int(user.ID),
+			Name:         user.Name,
+			Email:        user.Email,
+			Avatar:       user.Avatar,
+			Token:        pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresAt:    pair.ExpiresAt,
+		},
+	})
+}
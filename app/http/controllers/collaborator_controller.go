@@ -0,0 +1,348 @@
+package controllers
+
+import (
+	allerror "errors"
+	"strconv"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/collaborators"
+	"evote-be/app/services/users"
+)
+
+type CollaboratorController struct {
+	// Dependent services
+}
+
+func NewCollaboratorController() *CollaboratorController {
+	return &CollaboratorController{
+		// Inject services
+	}
+}
+
+// Index Get poll collaborators
+// @Summary Get poll collaborators
+// @Description Get the owner and the collaborators of a poll with their grants
+// @Tags Collaborators
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Success 200 {object} models.ResponseWithData[[]models.PollCollaboratorResponse] "Collaborators found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/collaborators [get]
+func (r *CollaboratorController) Index(ctx http.Context) http.Response {
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
+	}
+
+	// Get collaborators
+	resp, err := collaborators.List(poll)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get collaborators",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.PollCollaboratorResponse]{
+		Message: "Collaborators found",
+		Data:    resp,
+	})
+}
+
+// Invite Invite a collaborator
+// @Summary Invite a collaborator
+// @Description Email an invitation to collaborate on a poll as owner, editor or viewer.
+// @Description A pending invitation to the same email is replaced.
+// @Tags Collaborators
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.InviteCollaborator true "Invitation"
+// @Success 201 {object} models.ResponseWithData[models.PollInvitationResponse] "Invitation sent"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/collaborators/invite [post]
+func (r *CollaboratorController) Invite(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may manage the collaborators
+	if resp := deniedResponse(ctx, "poll.collaborators", poll); resp != nil {
+		return resp
+	}
+
+	// Validate request
+	var request requests.InviteCollaborator
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Send invitation, the inviter's name is shown in the email
+	invitation, err := collaborators.Invite(poll, user, request.Email, models.CollaboratorRole(request.Role))
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to send invitation",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.PollInvitationResponse]{
+		Message: "Invitation sent",
+		Data:    invitation.ToResponse(),
+	})
+}
+
+// Accept Accept an invitation
+// @Summary Accept an invitation
+// @Description Accept an invitation to collaborate on a poll. It must have been sent to the email of the user.
+// @Tags Collaborators
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.AcceptInvitation true "Invitation token"
+// @Success 200 {object} models.ResponseWithData[models.PollCollaboratorResponse] "Invitation accepted"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid invitation"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Invitation sent to another email"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/invitations/accept [post]
+func (r *CollaboratorController) Accept(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.AcceptInvitation
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Accept invitation, it is matched against the email of the account
+	collaborator, err := collaborators.Accept(request.Token, user)
+	if err != nil {
+		switch {
+		case allerror.Is(err, collaborators.ErrInvitationEmail):
+			return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+				Message: "Forbidden",
+				Errors:  err.Error(),
+			})
+		case allerror.Is(err, collaborators.ErrInvalidInvitation), allerror.Is(err, collaborators.ErrAlreadyOwner):
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Invalid invitation",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to accept invitation",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollCollaboratorResponse]{
+		Message: "Invitation accepted",
+		Data: models.PollCollaboratorResponse{
+			UserID: int(user.ID),
+			Name:   user.Name,
+			Email:  user.Email,
+			Role:   collaborator.Role,
+		},
+	})
+}
+
+// Delete Remove a collaborator
+// @Summary Remove a collaborator
+// @Description Revoke the grant of a collaborator on a poll. Collaborators may remove themselves.
+// @Tags Collaborators
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} models.ResponseWithMessage "Collaborator removed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll or collaborator not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/collaborators/{user_id}/delete [delete]
+func (r *CollaboratorController) Delete(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	userID, err := strconv.ParseUint(ctx.Request().Route("user_id"), 10, 64)
+	if err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Collaborator not found",
+			Errors:  "Invalid user id",
+		})
+	}
+
+	// Check if user may manage the collaborators, anyone may leave
+	if uint(userID) != user.ID {
+		if resp := deniedResponse(ctx, "poll.collaborators", poll); resp != nil {
+			return resp
+		}
+	}
+
+	// Remove collaborator
+	if err := collaborators.Remove(poll, uint(userID)); err != nil {
+		if allerror.Is(err, collaborators.ErrNotCollaborator) {
+			return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "Collaborator not found",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to remove collaborator",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Collaborator removed",
+	})
+}
+
+// Transfer Transfer poll ownership
+// @Summary Transfer poll ownership
+// @Description Make a collaborator the owner of the poll, the previous owner stays on as an editor
+// @Tags Collaborators
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransferPoll true "New owner"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Ownership transferred"
+// @Failure 400 {object} models.ErrorResponse "Validation error or user is not a collaborator"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/transfer [post]
+func (r *CollaboratorController) Transfer(ctx http.Context) http.Response {
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may transfer the poll
+	if resp := deniedResponse(ctx, "poll.transfer", poll); resp != nil {
+		return resp
+	}
+
+	// Validate request
+	var request requests.TransferPoll
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Transfer ownership
+	if err := collaborators.Transfer(poll, request.UserID); err != nil {
+		if allerror.Is(err, collaborators.ErrNotCollaborator) || allerror.Is(err, collaborators.ErrAlreadyOwner) {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to transfer poll",
+			Errors:  err.Error(),
+		})
+	}
+	poll.UserID = request.UserID
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollsResponse]{
+		Message: "Ownership transferred",
+		Data:    poll.ToResponse(),
+	})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/eligibility"
+	"evote-be/app/services/organizations"
+	"evote-be/app/services/users"
+)
+
+type EligibilityController struct {
+	// Dependent services
+}
+
+func NewEligibilityController() *EligibilityController {
+	return &EligibilityController{
+		// Inject services
+	}
+}
+
+// Show Get poll eligibility rules
+// @Summary Get poll eligibility rules
+// @Description Get the rules a user must meet to vote in a poll
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Success 200 {object} models.ResponseWithData[models.PollEligibilityResponse] "Eligibility rules found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/eligibility [get]
+func (r *EligibilityController) Show(ctx http.Context) http.Response {
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
+	}
+
+	// Get rules
+	rules, err := eligibility.Rules(poll.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollEligibilityResponse]{
+		Message: "Eligibility rules found",
+		Data:    rules.ToResponse(),
+	})
+}
+
+// Update Replace poll eligibility rules
+// @Summary Update poll eligibility rules
+// @Description Replace the rules a user must meet to vote in a poll. Rules cannot change once voting has begun.
+// @Description A required organization must be one the user is a member of.
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.UpdatePollEligibility true "Eligibility rules"
+// @Success 200 {object} models.ResponseWithData[models.PollEligibilityResponse] "Eligibility rules updated"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Poll content is locked"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/eligibility/update [put]
+func (r *EligibilityController) Update(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Who may vote cannot change once voting has begun
+	if resp := lockedPollResponse(ctx, poll); resp != nil {
+		return resp
+	}
+
+	// Validate request
+	var request requests.UpdatePollEligibility
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Organizations of others cannot be probed
+	if request.OrganizationID != nil {
+		role, err := organizations.RoleOf(*request.OrganizationID, user.ID)
+		if err != nil {
+			return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  err.Error(),
+			})
+		}
+		if role == "" {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  organizations.ErrNotFound.Error(),
+			})
+		}
+	}
+
+	// Domains are stored without a leading "@"
+	domains := make([]string, 0, len(request.AllowedDomains))
+	for _, domain := range request.AllowedDomains {
+		domains = append(domains, strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@")))
+	}
+
+	// Save rules
+	rules, err := eligibility.Save(models.PollEligibilityRules{
+		PollID:            poll.ID,
+		AllowedDomains:    strings.Join(domains, ","),
+		OrganizationID:    request.OrganizationID,
+		MinAccountAgeDays: request.MinAccountAgeDays,
+	})
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to update eligibility rules",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollEligibilityResponse]{
+		Message: "Eligibility rules updated",
+		Data:    rules.ToResponse(),
+	})
+}
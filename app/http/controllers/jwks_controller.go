@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/models"
+	"evote-be/app/services/signing"
+)
+
+type JwksController struct {
+	// Dependent services
+}
+
+func NewJwksController() *JwksController {
+	return &JwksController{
+		// Inject services
+	}
+}
+
+// Index Get the public keys access tokens are signed with
+// @Summary Get JSON Web Key Set
+// @Description Get the public keys access tokens are signed with, for other services to verify
+// @Description them. A token names its key in the kid header. Keys that were rotated out are
+// @Description listed until the tokens they signed expired.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} signing.JSONWebKeySet "Key set"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /.well-known/jwks.json [get]
+func (r *JwksController) Index(ctx http.Context) http.Response {
+	set, err := signing.JWKS()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Verifiers may cache the keys for as long as a rotation takes to apply
+	return ctx.Response().Header("Cache-Control", "public, max-age=60").Json(http.StatusOK, set)
+}
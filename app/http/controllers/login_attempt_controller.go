@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"math"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/lockout"
+)
+
+type LoginAttemptController struct {
+	// Dependent services
+}
+
+func NewLoginAttemptController() *LoginAttemptController {
+	return &LoginAttemptController{
+		// Inject services
+	}
+}
+
+// Index Get the login attempt log
+// @Summary Get login attempts
+// @Description Get the log of password logins, newest first
+// @Tags Login Attempts
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param email query string false "Email"
+// @Param ip_address query string false "IP address"
+// @Param successful query bool false "Successful"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} models.PaginateResponse[[]models.LoginAttemptResponse] "Login attempts found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /login-attempts [get]
+func (r *LoginAttemptController) Index(ctx http.Context) http.Response {
+	// Get query params
+	limit := ctx.Request().QueryInt("limit", 10)
+	offset := ctx.Request().QueryInt("offset", 0)
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Filter attempts
+	query := facades.Orm().Query().Model(&models.LoginAttempts{}).OrderBy("id", "desc")
+	if email := ctx.Request().Query("email"); email != "" {
+		query = query.Where("email = ?", lockout.Normalize(email))
+	}
+	if ip := ctx.Request().Query("ip_address"); ip != "" {
+		query = query.Where("ip_address = ?", ip)
+	}
+	if successful := ctx.Request().Query("successful"); successful != "" {
+		query = query.Where("successful = ?", ctx.Request().QueryBool("successful"))
+	}
+
+	// Get attempts
+	var attempts []models.LoginAttempts
+	if err := query.Limit(limit).Offset(offset).Find(&attempts); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Get total count
+	var total int64
+	if err := query.Count(&total); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.LoginAttemptResponse, len(attempts))
+	for i, attempt := range attempts {
+		resp[i] = attempt.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.PaginateResponse[[]models.LoginAttemptResponse]{
+		Message: "Login attempts found",
+		Data:    resp,
+		Meta: models.Meta{
+			Total:    int(total),
+			PerPage:  limit,
+			LastPage: int(math.Ceil(float64(total) / float64(limit))),
+			CurrPage: (offset / limit) + 1,
+		},
+	})
+}
@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"errors"
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/oidc"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type OIDCController struct {
+	// Dependent services
+}
+
+func NewOIDCController() *OIDCController {
+	return &OIDCController{
+		// Inject services
+	}
+}
+
+// Redirect Start a login with a provider
+// @Summary Start a login with a provider
+// @Description Get the page of the provider to send the user to. The provider sends the
+// @Description user back to the frontend with a code and the state, which are posted to
+// @Description the callback.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider, e.g. google, github or corporate"
+// @Success 200 {object} models.ResponseWithData[models.OIDCRedirectResponse] "Provider URL"
+// @Failure 404 {object} models.ErrorResponse "Provider not configured"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
+// @Router /auth/oidc/{provider}/redirect [get]
+func (r *OIDCController) Redirect(ctx http.Context) http.Response {
+	url, state, err := oidc.Start(ctx.Context(), ctx.Request().Route("provider"))
+	if err != nil {
+		if errors.Is(err, oidc.ErrUnknownProvider) {
+			return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "Provider not found",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.OIDCRedirectResponse]{
+		Message: "Redirect the user to the provider",
+		Data: models.OIDCRedirectResponse{
+			URL:   url,
+			State: state,
+		},
+	})
+}
+
+// Callback Complete a login with a provider
+// @Summary Complete a login with a provider
+// @Description Login with the code the provider sent the user back with. The account is
+// @Description linked to the user with the same email if the provider verified it, or a
+// @Description new user is created. Users with two-factor authentication get a challenge.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider, e.g. google, github or corporate"
+// @Param request body requests.OIDCCallback true "Code and state"
+// @Success 200 {object} models.ResponseWithData[models.UserLoginResponse] "Success response"
+// @Success 200 {object} models.ResponseWithData[models.TwoFactorChallengeResponse] "Two-factor authentication required"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid state"
+// @Failure 401 {object} models.ErrorResponse "Login rejected by the provider or email not verified"
+// @Failure 403 {object} models.ErrorResponse "Account banned"
+// @Failure 404 {object} models.ErrorResponse "Provider not configured"
+// @Failure 409 {object} models.ErrorResponse "Email belongs to another account"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
+// @Router /auth/oidc/{provider}/callback [post]
+func (r *OIDCController) Callback(ctx http.Context) http.Response {
+	// Validate request
+	var request requests.OIDCCallback
+	allerror, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if allerror != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  allerror.All(),
+		})
+	}
+
+	// Redeem code
+	provider := ctx.Request().Route("provider")
+	identity, err := oidc.Complete(ctx.Context(), provider, request.State, request.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, oidc.ErrUnknownProvider):
+			return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "Provider not found",
+				Errors:  err.Error(),
+			})
+		case errors.Is(err, oidc.ErrInvalidState):
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  http.Json{"state": err.Error()},
+			})
+		}
+
+		facades.Log().Warningf("Login with provider %s failed: %v", provider, err)
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Login with the provider failed",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Find, link or create user
+	user, err := oidc.ResolveUser(provider, identity)
+	if err != nil {
+		if errors.Is(err, oidc.ErrEmailTaken) {
+			return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  http.Json{"email": err.Error()},
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user is verified
+	if user.EmailVerifiedAt == nil {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "please verify your email address",
+			Errors:  http.Json{"email": "email not verified"},
+		})
+	}
+	if resp := bannedResponse(ctx, user); resp != nil {
+		return resp
+	}
+
+	return loginResponse(ctx, user)
+}
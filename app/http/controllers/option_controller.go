@@ -1,11 +1,14 @@
 package controllers
 
 import (
+	"evote-be/app/events"
 	"evote-be/app/http/requests"
 	"evote-be/app/models"
+	"evote-be/app/services/users"
 	"fmt"
 	"strconv"
 
+	"github.com/goravel/framework/contracts/event"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 )
@@ -37,10 +40,12 @@ func (r *OptionController) Index(ctx http.Context) http.Response {
 // @Success 201 {object} models.ResponseWithData[models.CreateOptionsResponse] "Option created"
 // @Failure 400 {object} models.ErrorResponse "Validation error"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Poll content is locked"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Router /options/create [post]
 func (r *OptionController) Store(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -78,19 +83,21 @@ func (r *OptionController) Store(ctx http.Context) http.Response {
 
 	// Check if poll exists
 	var poll models.Polls
-	if err := facades.Orm().Query().Model(&poll).Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query().Model(&poll)).Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
 			Message: "upss, something went wrong",
 			Errors:  "poll not found",
 		})
 	}
 
-	// Check if user is the owner of the poll
-	if poll.UserID != user.ID {
-		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
-			Message: "Unauthorized",
-			Errors:  "You are not the owner of this poll",
-		})
+	// Check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Options cannot change once voting has begun
+	if resp := lockedPollResponse(ctx, poll); resp != nil {
+		return resp
 	}
 
 	// Upload avatar to MinIO if avatar is exists
@@ -144,6 +151,7 @@ func (r *OptionController) Store(ctx http.Context) http.Response {
 			Errors:  err.Error(),
 		})
 	}
+	dispatchOptionChanged(option.PollID, option.ID, "created")
 
 	// Return response
 	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.CreateOptionsResponse]{
@@ -167,10 +175,12 @@ func (r *OptionController) Store(ctx http.Context) http.Response {
 // @Failure 400 {object} models.ErrorResponse "Validation error"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 404 {object} models.ErrorResponse "Option not found"
+// @Failure 409 {object} models.ErrorResponse "Poll content is locked"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Router /options/{id}/update [put]
 func (r *OptionController) Update(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -222,19 +232,21 @@ func (r *OptionController) Update(ctx http.Context) http.Response {
 
 	// Check if poll exists
 	var poll models.Polls
-	if err := query.Model(&poll).Where("id = ?", option.PollID).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, query.Model(&poll)).Where("id = ?", option.PollID).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
 			Message: "upss, something went wrong",
 			Errors:  "poll not found",
 		})
 	}
 
-	// Check if user is the owner of the poll
-	if poll.UserID != user.ID {
-		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
-			Message: "Unauthorized",
-			Errors:  "You are not the owner of this poll",
-		})
+	// Check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Options cannot change once voting has begun
+	if resp := lockedPollResponse(ctx, poll); resp != nil {
+		return resp
 	}
 
 	// Update option only if values are not empty
@@ -280,15 +292,36 @@ func (r *OptionController) Update(ctx http.Context) http.Response {
 		option.Avatar = url
 	}
 
-	// Check if poll_id is valid
-	pollID, err := strconv.ParseUint(request.PollID, 10, 64)
-	if err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
-			Message: "Validation error",
-			Errors:  "Invalid poll_id",
-		})
+	// Move option to another poll if poll_id is provided
+	if request.PollID != "" {
+		pollID, err := strconv.ParseUint(request.PollID, 10, 64)
+		if err != nil {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  "Invalid poll_id",
+			})
+		}
+
+		if uint(pollID) != option.PollID {
+			// Check if target poll exists and user may manage it
+			var target models.Polls
+			if err := scopePolls(ctx, query).Where("id = ?", pollID).FirstOrFail(&target); err != nil {
+				return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+					Message: "Poll not found",
+					Errors:  "poll not found",
+				})
+			}
+			if resp := deniedResponse(ctx, "poll.update", target); resp != nil {
+				return resp
+			}
+
+			// Options cannot change once voting has begun
+			if resp := lockedPollResponse(ctx, target); resp != nil {
+				return resp
+			}
+			option.PollID = target.ID
+		}
 	}
-	option.PollID = uint(pollID)
 
 	// Save option
 	if err := query.Save(&option); err != nil {
@@ -297,6 +330,7 @@ func (r *OptionController) Update(ctx http.Context) http.Response {
 			Errors:  err.Error(),
 		})
 	}
+	dispatchOptionChanged(option.PollID, option.ID, "updated")
 
 	// Return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.CreateOptionsResponse]{
@@ -316,10 +350,12 @@ func (r *OptionController) Update(ctx http.Context) http.Response {
 // @Success 200 {object} models.ResponseWithMessage "Option deleted"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 404 {object} models.ErrorResponse "Option not found"
+// @Failure 409 {object} models.ErrorResponse "Poll content is locked"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Router /options/{id}/delete [delete]
 func (r *OptionController) Delete(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	_, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -330,29 +366,56 @@ func (r *OptionController) Delete(ctx http.Context) http.Response {
 	// Get option id
 	optionID := ctx.Request().Route("id")
 
-	// Delete option
-	result, err := facades.Orm().Query().
-		Model(&models.Options{}).
-		Where("options.id = ? AND EXISTS (SELECT 1 FROM polls WHERE polls.id = options.poll_id AND polls.user_id = ?)",
-			optionID, user.ID).
-		Delete()
-	if err != nil {
-		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
-			Message: "Failed to delete option",
+	// Check if option exists
+	var option models.Options
+	if err := facades.Orm().Query().Where("id = ?", optionID).FirstOrFail(&option); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Option not found",
 			Errors:  err.Error(),
 		})
 	}
 
-	// Check if any row was affected (option existed and user owned it)
-	if result.RowsAffected == 0 {
+	// Check if poll exists
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", option.PollID).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
-			Message: "Option not found",
-			Errors:  "Option not found or you don't have permission to delete it",
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Options cannot change once voting has begun
+	if resp := lockedPollResponse(ctx, poll); resp != nil {
+		return resp
+	}
+
+	// Delete option
+	if _, err := facades.Orm().Query().Delete(&option); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to delete option",
+			Errors:  err.Error(),
 		})
 	}
+	dispatchOptionChanged(option.PollID, option.ID, "deleted")
 
 	// Return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
 		Message: "Option deleted successfully",
 	})
 }
+
+// dispatchOptionChanged fires the option changed event, failures are only logged
+func dispatchOptionChanged(pollID, optionID uint, action string) {
+	if err := facades.Event().Job(&events.OptionChanged{}, []event.Arg{
+		{Type: "uint", Value: pollID},
+		{Type: "uint", Value: optionID},
+		{Type: "string", Value: action},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch option changed event for option %d: %v", optionID, err)
+	}
+}
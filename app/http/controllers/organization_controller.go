@@ -0,0 +1,437 @@
+package controllers
+
+import (
+	allerror "errors"
+	"strconv"
+
+	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/organizations"
+	"evote-be/app/services/users"
+)
+
+type OrganizationController struct {
+	// Dependent services
+}
+
+func NewOrganizationController() *OrganizationController {
+	return &OrganizationController{
+		// Inject services
+	}
+}
+
+// Index Get organizations of the user
+// @Summary Get organizations
+// @Description Get the organizations the user is a member of with the role of the user.
+// @Description Send the id or slug in the X-Organization header to work in one.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.OrganizationResponse] "Organizations found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations [get]
+func (r *OrganizationController) Index(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get organizations
+	resp, err := organizations.ForUser(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get organizations",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.OrganizationResponse]{
+		Message: "Organizations found",
+		Data:    resp,
+	})
+}
+
+// Store Create an organization
+// @Summary Create an organization
+// @Description Create an organization, the user becomes its owner
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.CreateOrganization true "Organization"
+// @Success 201 {object} models.ResponseWithData[models.OrganizationResponse] "Organization created"
+// @Failure 400 {object} models.ErrorResponse "Validation error or slug already taken"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations/create [post]
+func (r *OrganizationController) Store(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.CreateOrganization
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Create organization
+	organization, err := organizations.Create(request.Name, request.Slug, user.ID)
+	if err != nil {
+		if allerror.Is(err, organizations.ErrSlugTaken) || allerror.Is(err, organizations.ErrInvalidSlug) {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to create organization",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.OrganizationResponse]{
+		Message: "Organization created",
+		Data:    organization.ToResponse(models.OrganizationOwner),
+	})
+}
+
+// Members Get organization members
+// @Summary Get organization members
+// @Description Get the members of an organization with their roles
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Organization ID"
+// @Success 200 {object} models.ResponseWithData[[]models.OrganizationMemberResponse] "Members found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Organization not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members [get]
+func (r *OrganizationController) Members(ctx http.Context) http.Response {
+	// Get organization of the route, members only
+	organization, _, resp := organizationOfRoute(ctx)
+	if resp != nil {
+		return resp
+	}
+
+	// Get members
+	members, err := organizations.Members(organization.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get members",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.OrganizationMemberResponse]{
+		Message: "Members found",
+		Data:    members,
+	})
+}
+
+// AddMember Add an organization member
+// @Summary Add an organization member
+// @Description Add a registered user to the organization. Only owners can add owners.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Organization ID"
+// @Param request body requests.AddOrganizationMember true "Member"
+// @Success 201 {object} models.ResponseWithData[models.OrganizationMemberResponse] "Member added"
+// @Failure 400 {object} models.ErrorResponse "Validation error, unknown email or already a member"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Organization not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members/add [post]
+func (r *OrganizationController) AddMember(ctx http.Context) http.Response {
+	// Get organization of the route, members only
+	organization, role, resp := organizationOfRoute(ctx)
+	if resp != nil {
+		return resp
+	}
+
+	// Validate request
+	var request requests.AddOrganizationMember
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Check if user may give the role
+	if resp := memberRoleDeniedResponse(ctx, role, models.OrganizationRole(request.Role)); resp != nil {
+		return resp
+	}
+
+	// Add member
+	member, err := organizations.AddMember(organization.ID, request.Email, models.OrganizationRole(request.Role))
+	if err != nil {
+		if allerror.Is(err, organizations.ErrUserNotFound) || allerror.Is(err, organizations.ErrAlreadyMember) {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to add member",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.OrganizationMemberResponse]{
+		Message: "Member added",
+		Data:    member,
+	})
+}
+
+// UpdateMember Change the role of an organization member
+// @Summary Update an organization member
+// @Description Change the role of a member. Only owners can change owners or make owners, the last owner cannot be demoted.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Param request body requests.UpdateOrganizationMember true "Role"
+// @Success 200 {object} models.ResponseWithMessage "Member updated"
+// @Failure 400 {object} models.ErrorResponse "Validation error or last owner"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Organization or member not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members/{user_id}/update [put]
+func (r *OrganizationController) UpdateMember(ctx http.Context) http.Response {
+	// Get organization of the route, members only
+	organization, role, resp := organizationOfRoute(ctx)
+	if resp != nil {
+		return resp
+	}
+
+	// Get member
+	userID, current, resp := memberOfRoute(ctx, organization)
+	if resp != nil {
+		return resp
+	}
+
+	// Validate request
+	var request requests.UpdateOrganizationMember
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Check if user may take the current role and give the new one
+	if resp := memberRoleDeniedResponse(ctx, role, current); resp != nil {
+		return resp
+	}
+	if resp := memberRoleDeniedResponse(ctx, role, models.OrganizationRole(request.Role)); resp != nil {
+		return resp
+	}
+
+	// Update member
+	if err := organizations.UpdateMember(organization.ID, userID, models.OrganizationRole(request.Role)); err != nil {
+		return memberErrorResponse(ctx, err)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Member updated",
+	})
+}
+
+// RemoveMember Remove an organization member
+// @Summary Remove an organization member
+// @Description Remove a member from the organization, members may leave. Polls of the member stay in the organization.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Organization ID"
+// @Param user_id path int true "User ID"
+// @Success 200 {object} models.ResponseWithMessage "Member removed"
+// @Failure 400 {object} models.ErrorResponse "Last owner"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Organization or member not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /organizations/{id}/members/{user_id}/delete [delete]
+func (r *OrganizationController) RemoveMember(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get organization of the route, members only
+	organization, role, resp := organizationOfRoute(ctx)
+	if resp != nil {
+		return resp
+	}
+
+	// Get member
+	userID, current, resp := memberOfRoute(ctx, organization)
+	if resp != nil {
+		return resp
+	}
+
+	// Check if user may remove the member, anyone may leave
+	if userID != user.ID {
+		if resp := memberRoleDeniedResponse(ctx, role, current); resp != nil {
+			return resp
+		}
+	}
+
+	// Remove member
+	if err := organizations.RemoveMember(organization.ID, userID); err != nil {
+		return memberErrorResponse(ctx, err)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Member removed",
+	})
+}
+
+// organizationOfRoute returns the organization of the route and the role of
+// the user in it, or a response when the user is not a member
+func organizationOfRoute(ctx http.Context) (models.Organizations, models.OrganizationRole, http.Response) {
+	user, ok := users.Current(ctx)
+	if !ok {
+		return models.Organizations{}, "", ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	organization, err := organizations.Find(ctx.Request().Route("id"))
+	if err != nil {
+		return models.Organizations{}, "", ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Organization not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Organizations of others are hidden
+	role, err := organizations.RoleOf(organization.ID, user.ID)
+	if err != nil {
+		return models.Organizations{}, "", ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if role == "" {
+		return models.Organizations{}, "", ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Organization not found",
+			Errors:  organizations.ErrNotFound.Error(),
+		})
+	}
+
+	return organization, role, nil
+}
+
+// memberOfRoute returns the user id of the route and the role of that member
+func memberOfRoute(ctx http.Context, organization models.Organizations) (uint, models.OrganizationRole, http.Response) {
+	userID, err := strconv.ParseUint(ctx.Request().Route("user_id"), 10, 64)
+	if err != nil {
+		return 0, "", ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Member not found",
+			Errors:  "Invalid user id",
+		})
+	}
+
+	role, err := organizations.RoleOf(organization.ID, uint(userID))
+	if err != nil {
+		return 0, "", ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if role == "" {
+		return 0, "", ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Member not found",
+			Errors:  organizations.ErrNotMember.Error(),
+		})
+	}
+
+	return uint(userID), role, nil
+}
+
+// memberRoleDeniedResponse returns a forbidden response when a member with the
+// given role may not manage members with the target role, or nil when allowed.
+// Managers handle admins and members, only owners handle owners.
+func memberRoleDeniedResponse(ctx http.Context, role, target models.OrganizationRole) http.Response {
+	if role == models.OrganizationOwner || (role.CanManage() && target != models.OrganizationOwner) {
+		return nil
+	}
+
+	return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+		Message: "Forbidden",
+		Errors:  "You are not allowed to manage " + string(target) + "s of this organization",
+	})
+}
+
+func memberErrorResponse(ctx http.Context, err error) http.Response {
+	switch {
+	case allerror.Is(err, organizations.ErrLastOwner):
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	case allerror.Is(err, organizations.ErrNotMember):
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Member not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+		Message: "Failed to update member",
+		Errors:  err.Error(),
+	})
+}
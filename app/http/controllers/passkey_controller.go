@@ -0,0 +1,351 @@
+package controllers
+
+import (
+	"errors"
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/passkey"
+	"evote-be/app/services/users"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type PasskeyController struct {
+	// Dependent services
+}
+
+func NewPasskeyController() *PasskeyController {
+	return &PasskeyController{
+		// Inject services
+	}
+}
+
+// Index Get passkeys of the user
+// @Summary Get passkeys
+// @Description Get the passkeys the user can login with
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.PasskeyCredentialResponse] "Passkeys found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/passkeys [get]
+func (r *PasskeyController) Index(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get passkeys
+	var passkeys []models.PasskeyCredentials
+	if err := facades.Orm().Query().Where("user_id = ?", user.ID).OrderBy("created_at", "desc").Find(&passkeys); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get passkeys",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.PasskeyCredentialResponse, len(passkeys))
+	for i, passkey := range passkeys {
+		resp[i] = passkey.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.PasskeyCredentialResponse]{
+		Message: "Passkeys found",
+		Data:    resp,
+	})
+}
+
+// RegistrationOptions Start registering a passkey
+// @Summary Start registering a passkey
+// @Description Get the options for navigator.credentials.create(). Binary values are
+// @Description base64url encoded and the challenge is valid for one registration.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[models.PasskeyCreationOptions] "Registration options"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/passkeys/register/options [post]
+func (r *PasskeyController) RegistrationOptions(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Create challenge
+	options, err := passkey.RegistrationOptions(user)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PasskeyCreationOptions]{
+		Message: "Passkey registration started",
+		Data:    options,
+	})
+}
+
+// Register Register a passkey
+// @Summary Register a passkey
+// @Description Store the passkey created by the authenticator for the registration options
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.RegisterPasskey true "Authenticator response"
+// @Success 201 {object} models.ResponseWithData[models.PasskeyCredentialResponse] "Passkey registered"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid response"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Passkey already registered"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/passkeys/register [post]
+func (r *PasskeyController) Register(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.RegisterPasskey
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Check response and store passkey
+	credential, err := passkey.Register(user.ID, request.Name, passkey.AttestationResponse{
+		ClientDataJSON:    request.ClientDataJSON,
+		AttestationObject: request.AttestationObject,
+	})
+	if err != nil {
+		return r.passkeyError(ctx, err, http.StatusBadRequest)
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.PasskeyCredentialResponse]{
+		Message: "Passkey registered successfully",
+		Data:    credential.ToResponse(),
+	})
+}
+
+// Delete Delete a passkey
+// @Summary Delete a passkey
+// @Description Remove a passkey, it can no longer be used to login
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Passkey ID"
+// @Success 200 {object} models.ResponseWithMessage "Passkey deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Passkey not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/passkeys/{id}/delete [delete]
+func (r *PasskeyController) Delete(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Check if passkey exists and belongs to user
+	var credential models.PasskeyCredentials
+	if err := facades.Orm().Query().
+		Where("id = ? AND user_id = ?", ctx.Request().Route("id"), user.ID).
+		FirstOrFail(&credential); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Passkey not found",
+			Errors:  "Passkey not found",
+		})
+	}
+
+	// Delete passkey
+	if _, err := facades.Orm().Query().Delete(&credential); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to delete passkey",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Passkey deleted successfully",
+	})
+}
+
+// LoginOptions Start a passkey login
+// @Summary Start a passkey login
+// @Description Get the options for navigator.credentials.get(). With an email only the
+// @Description passkeys of that account are allowed, without one the browser offers the
+// @Description passkeys it knows for the site.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body requests.PasskeyOptions false "Email"
+// @Success 200 {object} models.ResponseWithData[models.PasskeyRequestOptions] "Login options"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
+// @Router /auth/passkey/options [post]
+func (r *PasskeyController) LoginOptions(ctx http.Context) http.Response {
+	// Validate request
+	var request requests.PasskeyOptions
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Create challenge
+	options, err := passkey.LoginOptions(request.Email)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PasskeyRequestOptions]{
+		Message: "Passkey login started",
+		Data:    options,
+	})
+}
+
+// Login Login with a passkey
+// @Summary Login with a passkey
+// @Description Complete a passkey login with the response of the authenticator. Users with
+// @Description two-factor authentication continue with a code unless the authenticator
+// @Description verified them with a PIN or biometric.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body requests.PasskeyLogin true "Authenticator response"
+// @Success 200 {object} models.ResponseWithData[models.UserLoginResponse] "Success response"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Invalid passkey"
+// @Failure 403 {object} models.ErrorResponse "Account banned"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
+// @Router /auth/passkey/verify [post]
+func (r *PasskeyController) Login(ctx http.Context) http.Response {
+	// Validate request
+	var request requests.PasskeyLogin
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Check response
+	credential, assertion, err := passkey.Login(request.CredentialID, passkey.AssertionResponse{
+		ClientDataJSON:    request.ClientDataJSON,
+		AuthenticatorData: request.AuthenticatorData,
+		Signature:         request.Signature,
+		UserHandle:        request.UserHandle,
+	})
+	if err != nil {
+		return r.passkeyError(ctx, err, http.StatusUnauthorized)
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", credential.UserID).FirstOrFail(&user); err != nil {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  passkey.ErrUnknownCredential.Error(),
+		})
+	}
+
+	// Check if user is verified
+	if user.EmailVerifiedAt == nil {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "please verify your email address",
+			Errors:  http.Json{"email": "email not verified"},
+		})
+	}
+	if resp := bannedResponse(ctx, user); resp != nil {
+		return resp
+	}
+
+	// A passkey with user verification is two factors by itself, without it
+	// users with two-factor authentication continue with a code
+	if assertion.UserVerified {
+		return tokenResponse(ctx, user)
+	}
+
+	return loginResponse(ctx, user)
+}
+
+// passkeyError converts an error of checking an authenticator response to a
+// response, invalid responses get the given status
+func (r *PasskeyController) passkeyError(ctx http.Context, err error, status int) http.Response {
+	switch {
+	case errors.Is(err, passkey.ErrDuplicateKey):
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Passkey is already registered",
+			Errors:  err.Error(),
+		})
+	case errors.Is(err, passkey.ErrInvalidResponse),
+		errors.Is(err, passkey.ErrUnsupportedKey),
+		errors.Is(err, passkey.ErrInvalidSignature),
+		errors.Is(err, passkey.ErrClonedAuthenticator),
+		errors.Is(err, passkey.ErrChallengeMismatch),
+		errors.Is(err, passkey.ErrOriginNotAllowed),
+		errors.Is(err, passkey.ErrRelyingPartyMismatch),
+		errors.Is(err, passkey.ErrInvalidChallenge),
+		errors.Is(err, passkey.ErrUnknownCredential):
+		return ctx.Response().Json(status, models.ErrorResponse{
+			Message: "Invalid passkey",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+		Message: "ups, something went wrong",
+		Errors:  err.Error(),
+	})
+}
@@ -1,13 +1,23 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"evote-be/app/events"
 	"evote-be/app/http/requests"
 	"evote-be/app/models"
+	"evote-be/app/services/lifecycle"
+	"evote-be/app/services/rbac"
+	"evote-be/app/services/twofactor"
+	"evote-be/app/services/users"
 	"math"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/contracts/event"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 )
@@ -25,11 +35,12 @@ func NewPollsController() *PollsController {
 // Get all polls
 //
 // @Summary     Get all polls
-// @Description Get all polls
+// @Description Get the polls the user owns or collaborates on, or every poll for users who may view any poll
 // @Tags        Polls
 // @Accept      json
 // @Produce     json
 // @Security  Bearer
+// @Param       X-Organization header string false "ID or slug of the organization to work in, personal workspace when empty"
 // @Param       limit query int false "Limit"
 // @Param       offset query int false "Offset"
 // @Success 	 200 {object} models.ResponseWithData[[]models.PollsResponse] "Success response"
@@ -38,7 +49,7 @@ func NewPollsController() *PollsController {
 // @Router      /polls [get]
 func (r *PollsController) Index(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -52,8 +63,14 @@ func (r *PollsController) Index(ctx http.Context) http.Response {
 
 	// Get polls with optimized query
 	var polls []models.Polls
-	query := facades.Orm().Query().Model(&models.Polls{}).Where("user_id", user.ID).OrderBy("id", "desc")
-	if err := query.Limit(limit).Offset(offset).Select("id", "title", "description", "status", "start_date", "end_date", "code").Find(&polls); err != nil {
+	query := scopePolls(ctx, facades.Orm().Query().Model(&models.Polls{})).OrderBy("id", "desc")
+	// users who may view any poll and managers of the organization see every
+	// poll, others the polls they own or collaborate on
+	role, _ := ctx.Value("organization_role").(models.OrganizationRole)
+	if !rbac.Can(user.ID, rbac.PollViewAny) && !role.CanManage() {
+		query = query.Where("(user_id = ? OR id IN (SELECT poll_id FROM poll_collaborators WHERE user_id = ?))", user.ID, user.ID)
+	}
+	if err := query.Limit(limit).Offset(offset).Select("id", "title", "description", "status", "start_date", "end_date", "code", "organization_id").Find(&polls); err != nil {
 		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
 			Message: "Oops, something went wrong",
 			Errors:  err.Error(),
@@ -96,15 +113,17 @@ func (r *PollsController) Index(ctx http.Context) http.Response {
 // @Accept      json
 // @Produce     json
 // @Security  Bearer
+// @Param       X-Organization header string false "ID or slug of the organization to work in, personal workspace when empty"
 // @Param       request body requests.CreatePolling true "Poll Data"
 // @Success 	 201 {object} models.ResponseWithData[models.CreatePollingResponse] "Success response"
 // @Failure    	401 {object} models.ErrorResponse "Unauthorized"
 // @Failure     400 {object} models.ErrorResponse "Validation error or title already taken"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     403 {object} models.ErrorResponse "Forbidden"
 // @Router      /polls/create [post]
 func (r *PollsController) Store(ctx http.Context) http.Response {
 	// get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -128,23 +147,17 @@ func (r *PollsController) Store(ctx http.Context) http.Response {
 		})
 	}
 
-	if request.StartDate.Equal(time.Now().Truncate(time.Minute)) {
-		request.Status = string(models.Active)
-	}
-
-	if request.StartDate.After(time.Now().Truncate(time.Minute)) {
-		request.Status = string(models.Scheduled)
-	}
-
-	// create poll object
+	// create poll object, polls start as draft
 	poll := models.Polls{
 		Code:        nil,
 		Title:       request.Title,
 		Description: request.Description,
-		Status:      models.Status(request.Status),
+		Status:      models.Draft,
 		StartDate:   *request.StartDate,
 		EndDate:     request.EndDate,
 		UserID:      user.ID,
+		// polls belong to the organization the request works in
+		OrganizationID: currentOrganizationID(ctx),
 	}
 
 	// create poll
@@ -155,16 +168,36 @@ func (r *PollsController) Store(ctx http.Context) http.Response {
 		})
 	}
 
+	// fire poll events
+	dispatchPollEvent(&events.PollCreated{}, poll.ID)
+
+	// publish right away if requested, the poll stays a draft when it fails
+	message := "Poll created successfully"
+	publishError := ""
+	if request.Publish {
+		if err := publish(&poll, user.ID, "published on creation"); err != nil {
+			message = "Poll created as draft but could not be published"
+			publishError = err.Error()
+		}
+	}
+
+	code := ""
+	if poll.Code != nil {
+		code = *poll.Code
+	}
+
 	// return response
 	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.CreatePollingResponse]{
-		Message: "Poll created successfully",
+		Message: message,
 		Data: models.CreatePollingResponse{
-			ID:          int(poll.ID),
-			Title:       poll.Title,
-			Description: poll.Description,
-			Status:      poll.Status,
-			StartDate:   poll.StartDate,
-			EndDate:     poll.EndDate,
+			ID:           int(poll.ID),
+			Title:        poll.Title,
+			Description:  poll.Description,
+			Status:       poll.Status,
+			StartDate:    poll.StartDate,
+			EndDate:      poll.EndDate,
+			Code:         code,
+			PublishError: publishError,
 		},
 	})
 }
@@ -181,10 +214,11 @@ func (r *PollsController) Store(ctx http.Context) http.Response {
 // @Failure    	401 {object} models.ErrorResponse "Unauthorized"
 // @Failure     404 {object} models.ErrorResponse "Poll not found"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     403 {object} models.ErrorResponse "Forbidden"
 // @Router      /polls/{id} [get]
 func (r *PollsController) Show(ctx http.Context) http.Response {
 	// get user from context
-	user, ok := ctx.Value("user").(models.User)
+	_, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -197,13 +231,18 @@ func (r *PollsController) Show(ctx http.Context) http.Response {
 
 	// get poll
 	var poll models.Polls
-	if err := facades.Orm().Query().Where("user_id = ? AND id = ?", user.ID, id).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", id).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "Poll not found",
 			Errors:  err.Error(),
 		})
 	}
 
+	// check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
+	}
+
 	// return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollsResponse]{
 		Message: "Poll fetched successfully",
@@ -213,7 +252,8 @@ func (r *PollsController) Show(ctx http.Context) http.Response {
 
 // Update poll
 // @Summary     Update poll
-// @Description Update poll
+// @Description Update poll. Once voting has begun or votes were cast, only the
+// @Description end date can be extended and a reason is required; the change is recorded.
 // @Tags        Polls
 // @Accept      json
 // @Produce     json
@@ -224,11 +264,14 @@ func (r *PollsController) Show(ctx http.Context) http.Response {
 // @Failure    	401 {object} models.ErrorResponse "Unauthorized"
 // @Failure     400 {object} models.ErrorResponse "Validation error or title already taken"
 // @Failure     404 {object} models.ErrorResponse "Poll not found"
+// @Failure     409 {object} models.ErrorResponse "Poll content is locked"
+// @Failure     422 {object} models.ErrorResponse "End date of a locked poll not extended"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
 // @Router      /polls/{id}/update [put]
 func (r *PollsController) Update(ctx http.Context) http.Response {
 	// get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -236,18 +279,36 @@ func (r *PollsController) Update(ctx http.Context) http.Response {
 		})
 	}
 
+	// owners of large polls need two-factor authentication
+	if resp := twoFactorRequiredResponse(ctx, user); resp != nil {
+		return resp
+	}
+
 	// get poll id from path
 	id := ctx.Request().Route("id")
 
 	// get poll
 	var poll models.Polls
-	if err := facades.Orm().Query().Where("user_id = ? AND id = ?", user.ID, id).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", id).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "Poll not found",
 			Errors:  err.Error(),
 		})
 	}
 
+	// check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// status is changed through the dedicated transition endpoints only
+	if poll.Status.IsFinal() {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Poll can no longer be edited",
+			Errors:  "POLL_" + strings.ToUpper(string(poll.Status)),
+		})
+	}
+
 	// validate request
 	var request requests.UpdatePolling
 	errors, err := ctx.Request().ValidateRequest(&request)
@@ -264,7 +325,41 @@ func (r *PollsController) Update(ctx http.Context) http.Response {
 		})
 	}
 
+	// check if voting has begun on the poll
+	locked, err := lifecycle.IsLocked(poll)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// once voting has begun only the end date may be extended, with a reason
+	if locked {
+		if (request.Title != "" && request.Title != poll.Title) ||
+			(request.Description != "" && request.Description != poll.Description) ||
+			(!request.StartDate.IsZero() && !request.StartDate.Equal(poll.StartDate)) {
+			return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+				Message: "Poll content is locked once voting has begun, only the end date can be extended",
+				Errors:  "POLL_LOCKED",
+			})
+		}
+		if !request.EndDate.IsZero() && !request.EndDate.After(poll.EndDate) {
+			return ctx.Response().Json(http.StatusUnprocessableEntity, models.ErrorResponse{
+				Message: "The end date of a poll can only be extended once voting has begun",
+				Errors:  "END_DATE_NOT_EXTENDED",
+			})
+		}
+		if request.EndDate.After(poll.EndDate) && request.Reason == "" {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  "reason is required to amend a poll once voting has begun",
+			})
+		}
+	}
+
 	// update poll if value changed
+	previousEndDate := poll.EndDate
 	if request.Title != "" {
 		poll.Title = request.Title
 	}
@@ -277,17 +372,47 @@ func (r *PollsController) Update(ctx http.Context) http.Response {
 	if request.EndDate.After(poll.EndDate) {
 		poll.EndDate = request.EndDate
 	}
-	if request.Status != "" {
-		poll.Status = request.Status
-	}
 
-	// save poll
-	if err := facades.Orm().Query().Save(&poll); err != nil {
+	// save poll, amendments of a locked poll are recorded with it
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if err := tx.Save(&poll); err != nil {
+		tx.Rollback()
 		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
 			Message: "ups, something went wrong",
 			Errors:  err.Error(),
 		})
 	}
+	if locked && poll.EndDate.After(previousEndDate) {
+		if err := tx.Create(&models.PollAmendments{
+			PollID:   poll.ID,
+			Field:    "end_date",
+			OldValue: previousEndDate.Format(time.RFC3339),
+			NewValue: poll.EndDate.Format(time.RFC3339),
+			Reason:   request.Reason,
+			UserID:   user.ID,
+		}); err != nil {
+			tx.Rollback()
+			return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  err.Error(),
+			})
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// fire poll events
+	dispatchPollEvent(&events.PollUpdated{}, poll.ID)
 
 	// return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.UpdatePollingResponse]{
@@ -315,28 +440,40 @@ func (r *PollsController) Update(ctx http.Context) http.Response {
 // @Failure    	401 {object} models.ErrorResponse "Unauthorized"
 // @Failure     404 {object} models.ErrorResponse "Poll not found"
 // @Failure     500 {object} models.ErrorResponse "Internal server error"
+// @Failure     403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
 // @Router      /polls/{id}/delete [delete]
 func (r *PollsController) Delete(ctx http.Context) http.Response {
 	// get user from context
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
 			Errors:  "Invalid token",
 		})
 	}
+
+	// owners of large polls need two-factor authentication
+	if resp := twoFactorRequiredResponse(ctx, user); resp != nil {
+		return resp
+	}
+
 	// get poll id from path
 	id := ctx.Request().Route("id")
 
-	// Check if poll exists and belongs to user
+	// Check if poll exists
 	var poll models.Polls
-	if err := facades.Orm().Query().Model(&poll).Where("id = ? AND user_id = ?", id, user.ID).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query().Model(&poll)).Where("id = ?", id).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "something went wrong",
-			Errors:  "poll not found or you don't have permission",
+			Errors:  "poll not found",
 		})
 	}
 
+	// check if user may delete the poll
+	if resp := deniedResponse(ctx, "poll.delete", poll); resp != nil {
+		return resp
+	}
+
 	// Begin transaction
 	tx, err := facades.Orm().Query().Begin()
 	if err != nil {
@@ -372,6 +509,9 @@ func (r *PollsController) Delete(ctx http.Context) http.Response {
 		})
 	}
 
+	// Fire deleted event
+	dispatchPollEvent(&events.PollDeleted{}, poll.ID)
+
 	// return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
 		Message: "Poll deleted successfully",
@@ -389,10 +529,11 @@ func (r *PollsController) Delete(ctx http.Context) http.Response {
 // @Success 200 {object} models.ResponseWithData[models.CreateOptionsResponse] "Options found"
 // @Failure 401 {object} models.ErrorResponse "Unauthorized"
 // @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Router /polls/{id}/options [get]
 func (r *PollsController) GetPollOptions(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	_, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -414,19 +555,16 @@ func (r *PollsController) GetPollOptions(ctx http.Context) http.Response {
 
 	// Check if poll exists
 	var poll models.Polls
-	if err := facades.Orm().Query().Model(&poll).Where("id = ?", id).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query().Model(&poll)).Where("id = ?", id).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
 			Message: "upss, something went wrong",
 			Errors:  "poll not found",
 		})
 	}
 
-	// Check if user is the owner of the poll
-	if poll.UserID != user.ID {
-		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
-			Message: "Unauthorized",
-			Errors:  "You are not the owner of this poll",
-		})
+	// Check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
 	}
 
 	// Get all options of the poll
@@ -459,8 +597,12 @@ func (r *PollsController) GetPollOptions(ctx http.Context) http.Response {
 // @Accept json
 // @Produce json
 // @Param code query string true "Poll Code"
+// @Param X-Pow-Challenge header string false "Challenge of /pow/challenge, required when proof of work is on"
+// @Param X-Pow-Nonce header string false "Nonce solving the challenge, required when proof of work is on"
 // @Success 200 {object} models.ResponseWithData[models.PublicPollsResponse] "Polls found"
 // @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 428 {object} models.ErrorResponse "Proof of work required or invalid"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
 // @Router /polls/public [get]
 func (r *PollsController) GetPublicPolls(ctx http.Context) http.Response {
 	// Get query params from request
@@ -474,26 +616,45 @@ func (r *PollsController) GetPublicPolls(ctx http.Context) http.Response {
 		})
 	}
 
-	// Get poll and options by code
-	var poll models.Polls
-	if err := facades.Orm().Query().Model(&models.Polls{}).With("Options").Where("code = ?", code).FirstOrFail(&poll); err != nil {
+	// Get poll and options by code, cached as JSON until a listener forgets it
+	// so that every cache store can hold it
+	cached, err := facades.Cache().Remember(models.PublicPollCacheKey(code), time.Minute, func() (any, error) {
+		var poll models.Polls
+		if err := facades.Orm().Query().Model(&models.Polls{}).With("Options").Where("code = ?", code).FirstOrFail(&poll); err != nil {
+			return nil, err
+		}
+
+		// Convert poll to Public Polls Response
+		data, err := json.Marshal(poll.ToPublicResponse())
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	})
+	if err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "Poll not found",
 			Errors:  err.Error(),
 		})
 	}
+	var pollResp models.PublicPollsResponse
+	data, ok := cached.(string)
+	if !ok || json.Unmarshal([]byte(data), &pollResp) != nil {
+		facades.Cache().Forget(models.PublicPollCacheKey(code))
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  "invalid cached poll",
+		})
+	}
 
 	// Check if poll is active
-	if poll.Status != models.Active {
+	if pollResp.Status != models.Active {
 		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
 			Message: "The poll is currently inactive.",
 			Errors:  "POLL_INACTIVE",
 		})
 	}
 
-	// Convert poll to Public Polls Response
-	pollResp := poll.ToPublicResponse()
-
 	// Return response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PublicPollsResponse]{
 		Message: "Polls found",
@@ -511,10 +672,11 @@ func (r *PollsController) GetPublicPolls(ctx http.Context) http.Response {
 // @Param id path string true "Poll ID"
 // @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll code generated"
 // @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
 // @Router /polls/{id}/generate [get]
 func (r *PollsController) GeneratePublicPollCode(ctx http.Context) http.Response {
 	// Get user from context
-	user, ok := ctx.Value("user").(models.User)
+	_, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -525,15 +687,28 @@ func (r *PollsController) GeneratePublicPollCode(ctx http.Context) http.Response
 	// Get poll id from path
 	id := ctx.Request().Route("id")
 
-	// Check if poll exists and belongs to user
+	// Check if poll exists
 	var poll models.Polls
-	if err := facades.Orm().Query().Model(&poll).Where("id = ? AND user_id = ?", id, user.ID).FirstOrFail(&poll); err != nil {
+	if err := scopePolls(ctx, facades.Orm().Query().Model(&poll)).Where("id = ?", id).FirstOrFail(&poll); err != nil {
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "Poll not found",
 			Errors:  err.Error(),
 		})
 	}
 
+	// Check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Drafts get their code when they are published
+	if poll.Status == models.Draft {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Publish the poll before generating its code",
+			Errors:  "POLL_DRAFT",
+		})
+	}
+
 	// Generate public code
 	if poll.Code != nil {
 		return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollsResponse]{
@@ -559,6 +734,442 @@ func (r *PollsController) GeneratePublicPollCode(ctx http.Context) http.Response
 	})
 }
 
+// Publish poll
+// @Summary Publish a draft poll
+// @Description Publish a draft poll. It becomes Scheduled, or Active when its start date has passed,
+// @Description and gets its public code.
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll published"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/publish [post]
+func (r *PollsController) Publish(ctx http.Context) http.Response {
+	return r.transition(ctx, "", "published by owner")
+}
+
+// Pause poll
+// @Summary Pause an active poll
+// @Description Pause an active poll, votes are rejected until it is resumed
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll paused"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/pause [post]
+func (r *PollsController) Pause(ctx http.Context) http.Response {
+	return r.transition(ctx, models.Paused, "paused by owner")
+}
+
+// Resume poll
+// @Summary Resume a paused poll
+// @Description Resume a paused poll before its end date, other polls are rejected with 409
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll resumed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/resume [post]
+func (r *PollsController) Resume(ctx http.Context) http.Response {
+	return r.transition(ctx, models.Active, "resumed by owner")
+}
+
+// Close poll
+// @Summary Close a poll early
+// @Description Close an active or paused poll before its end date, results become final
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll closed"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/close [post]
+func (r *PollsController) Close(ctx http.Context) http.Response {
+	return r.transition(ctx, models.Done, "closed by owner")
+}
+
+// Cancel poll
+// @Summary Cancel a poll
+// @Description Cancel a poll that has not ended, its results are void
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll cancelled"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/cancel [post]
+func (r *PollsController) Cancel(ctx http.Context) http.Response {
+	return r.transition(ctx, models.Cancelled, "cancelled by owner")
+}
+
+// Archive poll
+// @Summary Archive a poll
+// @Description Archive a done or cancelled poll
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Param request body requests.TransitionPoll false "Transition reason"
+// @Success 200 {object} models.ResponseWithData[models.PollsResponse] "Poll archived"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden or two-factor authentication required"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 409 {object} models.ErrorResponse "Invalid transition"
+// @Router /polls/{id}/archive [post]
+func (r *PollsController) Archive(ctx http.Context) http.Response {
+	return r.transition(ctx, models.Archived, "archived by owner")
+}
+
+// Get poll status history
+// @Summary Get poll status history
+// @Description Get every status transition of a poll with its reason, oldest first
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Success 200 {object} models.ResponseWithData[[]models.PollTransitionResponse] "Transitions found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /polls/{id}/transitions [get]
+func (r *PollsController) Transitions(ctx http.Context) http.Response {
+	// get user from context
+	_, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
+	}
+
+	// get transitions
+	var transitions []models.PollTransitions
+	if err := facades.Orm().Query().Where("poll_id = ?", poll.ID).OrderBy("id").Find(&transitions); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	resp := make([]models.PollTransitionResponse, len(transitions))
+	for i, transition := range transitions {
+		resp[i] = transition.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.PollTransitionResponse]{
+		Message: "Transitions found",
+		Data:    resp,
+	})
+}
+
+// Get poll amendments
+// @Summary Get poll amendments
+// @Description Get every change made to a poll after voting had begun, oldest first
+// @Tags Polls
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Poll ID"
+// @Success 200 {object} models.ResponseWithData[[]models.PollAmendmentResponse] "Amendments found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /polls/{id}/amendments [get]
+func (r *PollsController) Amendments(ctx http.Context) http.Response {
+	// get user from context
+	_, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// check if user may view the poll
+	if resp := deniedResponse(ctx, "poll.view", poll); resp != nil {
+		return resp
+	}
+
+	// get amendments
+	var amendments []models.PollAmendments
+	if err := facades.Orm().Query().Where("poll_id = ?", poll.ID).OrderBy("id").Find(&amendments); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	resp := make([]models.PollAmendmentResponse, len(amendments))
+	for i, amendment := range amendments {
+		resp[i] = amendment.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.PollAmendmentResponse]{
+		Message: "Amendments found",
+		Data:    resp,
+	})
+}
+
+// transition moves the poll of the route to the given status, an empty
+// status publishes a draft.
+func (r *PollsController) transition(ctx http.Context, to models.Status, defaultReason string) http.Response {
+	// get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// owners of large polls need two-factor authentication
+	if resp := twoFactorRequiredResponse(ctx, user); resp != nil {
+		return resp
+	}
+
+	// get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// check if user may manage the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// validate request
+	var request requests.TransitionPoll
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	reason := request.Reason
+	if reason == "" {
+		reason = defaultReason
+	}
+
+	// only a paused poll can be resumed, drafts are published and scheduled
+	// polls start at their start date
+	if to == models.Active && poll.Status != models.Paused {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Only a paused poll can be resumed",
+			Errors:  "INVALID_TRANSITION",
+		})
+	}
+
+	// a paused poll cannot be resumed after its end date
+	if to == models.Active && !poll.EndDate.After(time.Now()) {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "The poll end date has passed",
+			Errors:  "POLL_ENDED",
+		})
+	}
+
+	if to == "" {
+		err = publish(&poll, user.ID, reason)
+	} else {
+		err = lifecycle.Transition(poll, to, reason, &user.ID)
+		poll.Status = to
+	}
+	if err != nil {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: err.Error(),
+			Errors:  "INVALID_TRANSITION",
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.PollsResponse]{
+		Message: "Poll is now " + string(poll.Status),
+		Data:    poll.ToResponse(),
+	})
+}
+
+// publish moves a draft to scheduled, or active when its start date has
+// passed, and gives it a public code.
+func publish(poll *models.Polls, userID uint, reason string) error {
+	now := time.Now()
+	if poll.Status != models.Draft {
+		return lifecycle.ErrInvalidTransition
+	}
+	if !poll.EndDate.After(now) {
+		return errors.New("the poll end date has passed")
+	}
+
+	// generate public code, it is set together with the status
+	code := randomString(6)
+	if poll.Code != nil {
+		code = *poll.Code
+	}
+
+	to := models.Active
+	if poll.StartDate.After(now) {
+		to = models.Scheduled
+	}
+	if err := lifecycle.Publish(*poll, to, code, reason, &userID); err != nil {
+		return err
+	}
+	poll.Code = &code
+	poll.Status = to
+
+	return nil
+}
+
+// scopePolls limits a query on polls to the organization the request works
+// in, or to personal workspaces when it names none
+func scopePolls(ctx http.Context, query orm.Query) orm.Query {
+	if organization, ok := ctx.Value("organization").(models.Organizations); ok {
+		return query.Where("polls.organization_id = ?", organization.ID)
+	}
+
+	return query.Where("polls.organization_id IS NULL")
+}
+
+// currentOrganizationID returns the id of the organization the request works
+// in, nil for the personal workspace
+func currentOrganizationID(ctx http.Context) *uint {
+	if organization, ok := ctx.Value("organization").(models.Organizations); ok {
+		return &organization.ID
+	}
+
+	return nil
+}
+
+// deniedResponse returns a forbidden response when the gate denies the user
+// the ability on the poll, or nil when it is allowed
+func deniedResponse(ctx http.Context, ability string, poll models.Polls) http.Response {
+	response := facades.Gate().WithContext(ctx).Inspect(ability, map[string]any{"poll": poll})
+	if response.Allowed() {
+		return nil
+	}
+
+	return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+		Message: "Forbidden",
+		Errors:  response.Message(),
+	})
+}
+
+// lockedPollResponse returns a conflict response when the content of the poll
+// is locked because voting has begun, or nil when it can still be edited
+func lockedPollResponse(ctx http.Context, poll models.Polls) http.Response {
+	locked, err := lifecycle.IsLocked(poll)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if locked {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Poll content is locked once voting has begun",
+			Errors:  "POLL_LOCKED",
+		})
+	}
+
+	return nil
+}
+
+// twoFactorRequiredResponse returns a forbidden response when the user owns a
+// poll large enough to require two-factor authentication but has not turned it
+// on, or nil when the user may manage polls
+func twoFactorRequiredResponse(ctx http.Context, user models.User) http.Response {
+	if user.TwoFactorConfirmedAt != nil {
+		return nil
+	}
+
+	required, err := twofactor.Required(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if required {
+		return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "Two-factor authentication is required for owners of large polls",
+			Errors:  "TWO_FACTOR_REQUIRED",
+		})
+	}
+
+	return nil
+}
+
+// dispatchPollEvent fires an event whose only argument is the poll id. Listener
+// failures are logged, the change they report has already been saved.
+func dispatchPollEvent(e event.Event, pollID uint) {
+	if err := facades.Event().Job(e, []event.Arg{
+		{Type: "uint", Value: pollID},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch poll event for poll %d: %v", pollID, err)
+	}
+}
+
 var randomizer = rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
 
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/models"
+	"evote-be/app/services/pow"
+)
+
+type ProofOfWorkController struct {
+	// Dependent services
+}
+
+func NewProofOfWorkController() *ProofOfWorkController {
+	return &ProofOfWorkController{
+		// Inject services
+	}
+}
+
+// Challenge Get a proof of work challenge
+// @Summary Get a proof of work challenge
+// @Description Get a challenge for endpoints guarded by proof of work. Find a nonce for which the
+// @Description SHA-256 hash of "challenge:nonce" starts with difficulty zero bits, and send both
+// @Description in the X-Pow-Challenge and X-Pow-Nonce headers. A challenge can be used once.
+// @Tags Proof of Work
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.ResponseWithData[pow.Challenge] "Challenge issued"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
+// @Router /pow/challenge [get]
+func (r *ProofOfWorkController) Challenge(ctx http.Context) http.Response {
+	challenge, err := pow.Issue(ctx.Request().Ip())
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[pow.Challenge]{
+		Message: "Challenge issued",
+		Data:    challenge,
+	})
+}
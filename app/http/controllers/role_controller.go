@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	allerror "errors"
+	"strconv"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/rbac"
+	"evote-be/app/services/users"
+)
+
+type RoleController struct {
+	// Dependent services
+}
+
+func NewRoleController() *RoleController {
+	return &RoleController{
+		// Inject services
+	}
+}
+
+// Index Get roles
+// @Summary Get roles
+// @Description Get the roles with their permissions
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.RoleResponse] "Roles found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /roles [get]
+func (r *RoleController) Index(ctx http.Context) http.Response {
+	// Get roles
+	var roles []models.Roles
+	if err := facades.Orm().Query().OrderBy("id").Find(&roles); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get roles",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Get permissions of each role
+	resp := make([]models.RoleResponse, len(roles))
+	for i, role := range roles {
+		var permissions []string
+		if err := facades.Orm().Query().Model(&models.Permissions{}).
+			Where("id IN (SELECT permission_id FROM role_permissions WHERE role_id = ?)", role.ID).
+			OrderBy("name").
+			Pluck("name", &permissions); err != nil {
+			return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+				Message: "Failed to get roles",
+				Errors:  err.Error(),
+			})
+		}
+
+		resp[i] = models.RoleResponse{
+			ID:          int(role.ID),
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: permissions,
+		}
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.RoleResponse]{
+		Message: "Roles found",
+		Data:    resp,
+	})
+}
+
+// UpdateUserRoles Replace the roles of a user
+// @Summary Update user roles
+// @Description Replace the roles of another user, the change applies within a minute
+// @Tags Roles
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "User ID"
+// @Param request body requests.UpdateUserRoles true "Roles"
+// @Success 200 {object} models.ResponseWithData[models.UserRolesResponse] "Roles updated"
+// @Failure 400 {object} models.ErrorResponse "Validation error or unknown role"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "User not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/{id}/roles/update [put]
+func (r *RoleController) UpdateUserRoles(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get target user
+	id, err := strconv.ParseUint(ctx.Request().Route("id"), 10, 64)
+	if err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "User not found",
+			Errors:  "Invalid user id",
+		})
+	}
+	var target models.User
+	if err := facades.Orm().Query().Where("id = ?", id).FirstOrFail(&target); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "User not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Admins cannot lock themselves out
+	if target.ID == user.ID {
+		return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "Forbidden",
+			Errors:  "You cannot change your own roles",
+		})
+	}
+
+	// Validate request
+	var request requests.UpdateUserRoles
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Replace roles
+	if err := rbac.Sync(target.ID, request.Roles); err != nil {
+		if allerror.Is(err, rbac.ErrUnknownRole) {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  err.Error(),
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to update roles",
+			Errors:  err.Error(),
+		})
+	}
+
+	roles, err := rbac.Roles(target.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get roles",
+			Errors:  err.Error(),
+		})
+	}
+
+	facades.Log().Infof("User %d set the roles of user %d to %v", user.ID, target.ID, roles)
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.UserRolesResponse]{
+		Message: "Roles updated",
+		Data: models.UserRolesResponse{
+			UserID: int(target.ID),
+			Roles:  roles,
+		},
+	})
+}
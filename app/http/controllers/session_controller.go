@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type SessionController struct {
+	// Dependent services
+}
+
+func NewSessionController() *SessionController {
+	return &SessionController{
+		// Inject services
+	}
+}
+
+// Index Get active sessions of the user
+// @Summary Get active sessions
+// @Description Get the devices the user is logged in on, most recently used first
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.UserSessionResponse] "Sessions found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/sessions [get]
+func (r *SessionController) Index(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+	currentID, _ := ctx.Value("session_id").(uint)
+
+	// Get sessions that are neither revoked nor expired
+	var sessions []models.UserSessions
+	if err := facades.Orm().Query().
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", user.ID, time.Now()).
+		OrderBy("last_seen_at", "desc").
+		Find(&sessions); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get sessions",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.UserSessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = session.ToResponse(currentID)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.UserSessionResponse]{
+		Message: "Sessions found",
+		Data:    resp,
+	})
+}
+
+// Delete Revoke a session
+// @Summary Revoke a session
+// @Description Sign out a device, its access and refresh tokens stop working
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Session ID"
+// @Success 200 {object} models.ResponseWithMessage "Session revoked"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Session not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/sessions/{id}/delete [delete]
+func (r *SessionController) Delete(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Check if session exists and belongs to user
+	var session models.UserSessions
+	if err := facades.Orm().Query().
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", ctx.Request().Route("id"), user.ID).
+		FirstOrFail(&session); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Session not found",
+			Errors:  "Session not found or already revoked",
+		})
+	}
+
+	// Revoke session
+	if err := tokens.RevokeFamily(session.FamilyID); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to revoke session",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Session revoked successfully",
+	})
+}
+
+// RevokeOthers Revoke every session except the current one
+// @Summary Revoke other sessions
+// @Description Sign out every other device of the user
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithMessage "Sessions revoked"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/sessions/revoke-others [post]
+func (r *SessionController) RevokeOthers(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+	currentID, _ := ctx.Value("session_id").(uint)
+
+	// Get other sessions
+	var families []string
+	if err := facades.Orm().Query().Model(&models.UserSessions{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", user.ID, currentID).
+		Pluck("family_id", &families); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to revoke sessions",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Revoke them
+	for _, family := range families {
+		if err := tokens.RevokeFamily(family); err != nil {
+			return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+				Message: "Failed to revoke sessions",
+				Errors:  err.Error(),
+			})
+		}
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Other sessions revoked successfully",
+	})
+}
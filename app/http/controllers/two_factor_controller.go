@@ -0,0 +1,319 @@
+package controllers
+
+import (
+	"errors"
+	"evote-be/app/http/requests"
+	"evote-be/app/models"
+	"evote-be/app/services/twofactor"
+	"evote-be/app/services/users"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type TwoFactorController struct {
+	// Dependent services
+}
+
+func NewTwoFactorController() *TwoFactorController {
+	return &TwoFactorController{
+		// Inject services
+	}
+}
+
+// Enable Start two-factor enrollment
+// @Summary Start two-factor enrollment
+// @Description Generate a new TOTP secret. The URI is shown as a QR code for the
+// @Description authenticator app; two-factor authentication is on once confirmed.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[models.TwoFactorSetupResponse] "Secret generated"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/two-factor/enable [post]
+func (r *TwoFactorController) Enable(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := r.user(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Confirmed secrets are only replaced after disabling
+	if user.TwoFactorConfirmedAt != nil {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Two-factor authentication is already enabled",
+			Errors:  "TWO_FACTOR_ENABLED",
+		})
+	}
+
+	// Generate secret
+	secret, err := twofactor.Enable(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.TwoFactorSetupResponse]{
+		Message: "Scan the QR code with your authenticator app and confirm with a code",
+		Data: models.TwoFactorSetupResponse{
+			Secret: secret,
+			URI:    twofactor.URI(facades.Config().GetString("auth.two_factor.issuer", "Evote"), user.Email, secret),
+		},
+	})
+}
+
+// Confirm Confirm two-factor enrollment
+// @Summary Confirm two-factor enrollment
+// @Description Turn on two-factor authentication with a code of the authenticator app.
+// @Description The recovery codes are only returned once.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.TwoFactorCode true "Code"
+// @Success 200 {object} models.ResponseWithData[models.TwoFactorRecoveryCodesResponse] "Two-factor authentication enabled"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid code"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication already enabled"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/two-factor/confirm [post]
+func (r *TwoFactorController) Confirm(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := r.user(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.TwoFactorCode
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	if user.TwoFactorConfirmedAt != nil {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Two-factor authentication is already enabled",
+			Errors:  "TWO_FACTOR_ENABLED",
+		})
+	}
+
+	// Check code and turn on two-factor authentication
+	codes, err := twofactor.Confirm(user, request.Code)
+	if err != nil {
+		return r.codeError(ctx, err)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.TwoFactorRecoveryCodesResponse]{
+		Message: "Two-factor authentication enabled, store the recovery codes in a safe place",
+		Data: models.TwoFactorRecoveryCodesResponse{
+			RecoveryCodes: codes,
+		},
+	})
+}
+
+// RecoveryCodes Regenerate recovery codes
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes, the previous ones stop working
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.TwoFactorCode true "Code"
+// @Success 200 {object} models.ResponseWithData[models.TwoFactorRecoveryCodesResponse] "Recovery codes regenerated"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid code"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication not enabled"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/two-factor/recovery-codes [post]
+func (r *TwoFactorController) RecoveryCodes(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := r.user(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.TwoFactorCode
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	if user.TwoFactorConfirmedAt == nil {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Two-factor authentication is not enabled",
+			Errors:  "TWO_FACTOR_DISABLED",
+		})
+	}
+
+	// Check code
+	if err := twofactor.Verify(user, request.Code); err != nil {
+		return r.codeError(ctx, err)
+	}
+
+	// Replace recovery codes
+	codes, err := twofactor.ReplaceRecoveryCodes(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.TwoFactorRecoveryCodesResponse]{
+		Message: "Recovery codes regenerated, store them in a safe place",
+		Data: models.TwoFactorRecoveryCodesResponse{
+			RecoveryCodes: codes,
+		},
+	})
+}
+
+// Disable Turn off two-factor authentication
+// @Summary Turn off two-factor authentication
+// @Description Turn off two-factor authentication with the password and a code. Owners
+// @Description of polls that require two-factor authentication cannot turn it off.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.DisableTwoFactor true "Password and code"
+// @Success 200 {object} models.ResponseWithMessage "Two-factor authentication disabled"
+// @Failure 400 {object} models.ErrorResponse "Validation error or invalid code"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized or wrong password"
+// @Failure 409 {object} models.ErrorResponse "Two-factor authentication not enabled or required"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /users/two-factor/disable [post]
+func (r *TwoFactorController) Disable(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := r.user(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.DisableTwoFactor
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	if user.TwoFactorConfirmedAt == nil {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Two-factor authentication is not enabled",
+			Errors:  "TWO_FACTOR_DISABLED",
+		})
+	}
+
+	// Owners of large polls must keep it on
+	required, err := twofactor.Required(user.ID)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+	if required {
+		return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+			Message: "Two-factor authentication is required for owners of large polls",
+			Errors:  "TWO_FACTOR_REQUIRED",
+		})
+	}
+
+	// Check password and code
+	if !facades.Hash().Check(request.Password, user.Password) {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  http.Json{"password": "password not match"},
+		})
+	}
+	if err := twofactor.Verify(user, request.Code); err != nil {
+		return r.codeError(ctx, err)
+	}
+
+	// Turn off two-factor authentication
+	if err := twofactor.Disable(user.ID); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Two-factor authentication disabled",
+	})
+}
+
+// user loads the authenticated user with the two-factor columns
+func (r *TwoFactorController) user(ctx http.Context) (models.User, bool) {
+	authUser, ok := users.Current(ctx)
+	if !ok {
+		return models.User{}, false
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", authUser.ID).FirstOrFail(&user); err != nil {
+		return models.User{}, false
+	}
+
+	return user, true
+}
+
+// codeError converts an error of checking a code to a response
+func (r *TwoFactorController) codeError(ctx http.Context, err error) http.Response {
+	if errors.Is(err, twofactor.ErrInvalidCode) {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  http.Json{"code": err.Error()},
+		})
+	}
+
+	return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+		Message: "ups, something went wrong",
+		Errors:  err.Error(),
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"evote-be/app/http/requests"
 	"evote-be/app/models"
+	"evote-be/app/services/users"
 	"fmt"
+	"time"
 
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
@@ -41,7 +43,7 @@ func (r *UserController) Show(ctx http.Context) http.Response {
 // @Router /users/update [put]
 func (r *UserController) Update(ctx http.Context) http.Response {
 	// Get user from context
-	u, ok := ctx.Value("user").(models.User)
+	u, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -66,25 +68,21 @@ func (r *UserController) Update(ctx http.Context) http.Response {
 		})
 	}
 
-	// Get user data
-	var user models.User
-	if err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", u.ID).First(&user); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
-			Message: "Failed to update user",
-			Errors:  "User not found",
-		})
-	}
-
-	// Update fields if not empty
+	// Update fields if not empty, only the changed columns are written as the
+	// user of the context may be cached
+	user := u
+	fields := map[string]any{"updated_at": time.Now()}
 	if request.Name != "" {
 		user.Name = request.Name
+		fields["name"] = user.Name
 	}
 	if request.Email != "" {
 		user.Email = request.Email
+		fields["email"] = user.Email
 	}
 
 	// Update user
-	result, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", u.ID).Update(&user)
+	result, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", u.ID).Update(fields)
 	if err != nil {
 		var pgError *pgconn.PgError
 		if errors.As(err, &pgError) {
@@ -109,6 +107,7 @@ func (r *UserController) Update(ctx http.Context) http.Response {
 			Errors:  "User not found",
 		})
 	}
+	users.Forget(u.ID)
 
 	// Return success response
 	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.UserRegisterResponse]{
@@ -137,7 +136,7 @@ func (r *UserController) Update(ctx http.Context) http.Response {
 // TODO: Compress image before storing |  limit file size
 func (r *UserController) UploadAvatar(ctx http.Context) http.Response {
 	// Get user from context
-	u, ok := ctx.Value("user").(models.User)
+	u, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -222,6 +221,7 @@ func (r *UserController) UploadAvatar(ctx http.Context) http.Response {
 			Errors:  err.Error(),
 		})
 	}
+	users.Forget(u.ID)
 
 	// Return success response
 	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.UserRegisterResponse]{
@@ -247,7 +247,7 @@ func (r *UserController) UploadAvatar(ctx http.Context) http.Response {
 // @Router /users/profile [get]
 func (r *UserController) GetProfile(ctx http.Context) http.Response {
 	// Get user from context
-	u, ok := ctx.Value("user").(models.User)
+	u, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -255,23 +255,14 @@ func (r *UserController) GetProfile(ctx http.Context) http.Response {
 		})
 	}
 
-	// Get user data
-	var user models.User
-	if err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", u.ID).First(&user); err != nil {
-		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
-			Message: "Failed to get user profile",
-			Errors:  "User not found",
-		})
-	}
-
 	// Return success response
 	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.UserRegisterResponse]{
 		Message: "user profile",
 		Data: models.UserRegisterResponse{
-			ID:     int(user.ID),
-			Name:   user.Name,
-			Email:  user.Email,
-			Avatar: user.Avatar,
+			ID:     int(u.ID),
+			Name:   u.Name,
+			Email:  u.Email,
+			Avatar: u.Avatar,
 		},
 	})
 }
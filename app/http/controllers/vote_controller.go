@@ -1,10 +1,19 @@
 package controllers
 
 import (
+	allerror "errors"
+	"evote-be/app/events"
 	"evote-be/app/http/requests"
 	"evote-be/app/models"
+	"evote-be/app/services/ballot"
+	"evote-be/app/services/eligibility"
+	"evote-be/app/services/users"
+	"math"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/goravel/framework/contracts/event"
 	"github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
 )
@@ -17,16 +26,21 @@ func NewVoteController() *VoteController {
 }
 
 // @Summary Record a vote
-// @Description Record a vote for a poll option
+// @Description Record a vote for a poll option. The vote is scored for signs of ballot stuffing
+// @Description and flagged for review by the owner of the poll when suspicious.
 // @Tags Vote
 // @Accept json
 // @Produce json
 // @Security Bearer
+// @Param X-Organization header string false "ID or slug of the organization to work in, personal workspace when empty"
+// @Param X-Device-Fingerprint header string false "Fingerprint of the device, stored hashed"
 // @Param request body requests.CreateVote true "Poll Data"
+// @Failure 403 {object} models.ErrorResponse "Forbidden with EMAIL_NOT_VERIFIED, or not eligible with EMAIL_DOMAIN_NOT_ALLOWED, ORGANIZATION_MEMBERSHIP_REQUIRED or ACCOUNT_TOO_NEW"
+// @Failure 429 {object} models.ErrorResponse "Too many requests"
 // @Router /votes/create [post]
 // Get user from context
 func (r *VoteController) Store(ctx http.Context) http.Response {
-	user, ok := ctx.Value("user").(models.User)
+	user, ok := users.Current(ctx)
 	if !ok {
 		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
 			Message: "Unauthorized",
@@ -72,7 +86,7 @@ func (r *VoteController) Store(ctx http.Context) http.Response {
 
 	// Get poll by code with a single query
 	var poll models.Polls
-	if err := tx.Where("code = ?", request.Code).First(&poll); err != nil {
+	if err := scopePolls(ctx, tx).Where("code = ?", request.Code).First(&poll); err != nil {
 		tx.Rollback()
 		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
 			Message: "Poll not found",
@@ -89,6 +103,22 @@ func (r *VoteController) Store(ctx http.Context) http.Response {
 		})
 	}
 
+	// Check if user may vote in the poll
+	if err := eligibility.Evaluate(poll.ID, user); err != nil {
+		tx.Rollback()
+		var ineligible *eligibility.Error
+		if allerror.As(err, &ineligible) {
+			return ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+				Message: ineligible.Message,
+				Errors:  ineligible.Code,
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to check eligibility",
+			Errors:  "Database error occurred when checking eligibility",
+		})
+	}
+
 	// Check if option exists and belongs to the poll in a single query
 	var option models.Options
 	if err := tx.Where("id = ? AND poll_id = ?", optionID, poll.ID).FirstOrFail(&option); err != nil {
@@ -117,11 +147,28 @@ func (r *VoteController) Store(ctx http.Context) http.Response {
 		})
 	}
 
+	// Score the vote for signs of ballot stuffing
+	metadata := ballot.NewMetadata(ctx.Request().Ip(), ctx.Request().Header("User-Agent", ""), ctx.Request().Header("X-Device-Fingerprint", ""))
+	assessment, err := ballot.Assess(tx, poll.ID, user, metadata, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to record vote",
+			Errors:  "Database error occurred when checking the vote",
+		})
+	}
+
 	// Create vote record
 	vote := models.Votes{
-		UserID:   user.ID,
-		PollID:   poll.ID,
-		OptionID: uint(optionID),
+		UserID:      user.ID,
+		PollID:      poll.ID,
+		OptionID:    uint(optionID),
+		IPHash:      metadata.IPHash,
+		UserAgent:   metadata.UserAgent,
+		Fingerprint: metadata.Fingerprint,
+		RiskScore:   assessment.Score,
+		RiskSignals: strings.Join(assessment.Signals, ","),
+		Flagged:     assessment.Flagged,
 	}
 
 	if err := tx.Create(&vote); err != nil {
@@ -152,7 +199,196 @@ func (r *VoteController) Store(ctx http.Context) http.Response {
 		})
 	}
 
+	// Fire vote cast event
+	if err := facades.Event().Job(&events.VoteCast{}, []event.Arg{
+		{Type: "uint", Value: poll.ID},
+		{Type: "uint", Value: option.ID},
+		{Type: "uint", Value: vote.ID},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch vote cast event for poll %d: %v", poll.ID, err)
+	}
+
 	return ctx.Response().Json(http.StatusCreated, models.ResponseWithMessage{
 		Message: "Vote recorded successfully",
 	})
 }
+
+// Flagged Get the flagged votes of a poll
+// @Summary Get flagged votes
+// @Description Get the votes of a poll that were flagged as suspicious, highest score first
+// @Tags Vote
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X-Organization header string false "ID or slug of the organization to work in, personal workspace when empty"
+// @Param id path int true "Poll ID"
+// @Param status query string false "Review status: pending, approved or quarantined"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} models.PaginateResponse[[]models.VoteReviewResponse] "Flagged votes found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/votes/flagged [get]
+func (r *VoteController) Flagged(ctx http.Context) http.Response {
+	// Get query params
+	limit := ctx.Request().QueryInt("limit", 10)
+	offset := ctx.Request().QueryInt("offset", 0)
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may review the votes of the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Filter votes
+	query := facades.Orm().Query().Model(&models.Votes{}).Where("poll_id = ? AND flagged = ?", poll.ID, true)
+	switch ctx.Request().Query("status") {
+	case "pending":
+		query = query.Where("review_status = ?", "")
+	case models.VoteApproved, models.VoteQuarantined:
+		query = query.Where("review_status = ?", ctx.Request().Query("status"))
+	}
+
+	// Get votes
+	var votes []models.Votes
+	if err := query.OrderBy("risk_score", "desc").OrderBy("id").Limit(limit).Offset(offset).Find(&votes); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Get total count
+	var total int64
+	if err := query.Count(&total); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.VoteReviewResponse, len(votes))
+	for i, vote := range votes {
+		resp[i] = vote.ToReviewResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.PaginateResponse[[]models.VoteReviewResponse]{
+		Message: "Flagged votes found",
+		Data:    resp,
+		Meta: models.Meta{
+			Total:    int(total),
+			PerPage:  limit,
+			LastPage: int(math.Ceil(float64(total) / float64(limit))),
+			CurrPage: (offset / limit) + 1,
+		},
+	})
+}
+
+// Review Approve or quarantine a vote
+// @Summary Review a vote
+// @Description Approve a vote or quarantine it. Quarantined votes don't count towards the
+// @Description results until they are approved.
+// @Tags Vote
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X-Organization header string false "ID or slug of the organization to work in, personal workspace when empty"
+// @Param id path int true "Poll ID"
+// @Param vote_id path int true "Vote ID"
+// @Param request body requests.ReviewVote true "Review"
+// @Success 200 {object} models.ResponseWithData[models.VoteReviewResponse] "Vote reviewed"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Failure 404 {object} models.ErrorResponse "Poll or vote not found"
+// @Failure 409 {object} models.ErrorResponse "Poll not active or paused, or reviewed by someone else in the meantime"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Router /polls/{id}/votes/{vote_id}/review [put]
+func (r *VoteController) Review(ctx http.Context) http.Response {
+	// Validate request
+	var request requests.ReviewVote
+	if errors, err := ctx.Request().ValidateRequest(&request); err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	} else if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Get poll
+	var poll models.Polls
+	if err := scopePolls(ctx, facades.Orm().Query()).Where("id = ?", ctx.Request().Route("id")).FirstOrFail(&poll); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Poll not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if user may review the votes of the poll
+	if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+		return resp
+	}
+
+	// Get vote
+	var vote models.Votes
+	if err := facades.Orm().Query().Where("id = ? AND poll_id = ?", ctx.Request().Route("vote_id"), poll.ID).FirstOrFail(&vote); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Vote not found",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Review vote
+	vote, err := ballot.Review(poll, vote, request.Status)
+	if err != nil {
+		if allerror.Is(err, ballot.ErrReviewConflict) {
+			return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+				Message: err.Error(),
+				Errors:  "REVIEW_CONFLICT",
+			})
+		}
+		if allerror.Is(err, ballot.ErrPollClosed) {
+			return ctx.Response().Json(http.StatusConflict, models.ErrorResponse{
+				Message: err.Error(),
+				Errors:  "POLL_CLOSED",
+			})
+		}
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to review vote",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Fire vote reviewed event
+	if err := facades.Event().Job(&events.VoteReviewed{}, []event.Arg{
+		{Type: "uint", Value: poll.ID},
+		{Type: "uint", Value: vote.OptionID},
+		{Type: "uint", Value: vote.ID},
+		{Type: "string", Value: vote.ReviewStatus},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch vote reviewed event for poll %d: %v", poll.ID, err)
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[models.VoteReviewResponse]{
+		Message: "Vote reviewed",
+		Data:    vote.ToReviewResponse(),
+	})
+}
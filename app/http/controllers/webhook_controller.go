@@ -0,0 +1,366 @@
+package controllers
+
+import (
+	"evote-be/app/http/requests"
+	"evote-be/app/jobs"
+	"evote-be/app/models"
+	"evote-be/app/services/users"
+	"evote-be/app/services/webhook"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+type WebhookController struct {
+	// Dependent services
+}
+
+func NewWebhookController() *WebhookController {
+	return &WebhookController{
+		// Inject services
+	}
+}
+
+// Index Get all webhooks of the user
+// @Summary Get all webhooks
+// @Description Get all webhooks registered by the user
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} models.ResponseWithData[[]models.WebhookResponse] "Webhooks found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 500 {object} models.ErrorResponse "Internal server error"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks [get]
+func (r *WebhookController) Index(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get webhooks
+	var webhooks []models.Webhooks
+	if err := facades.Orm().Query().Where("user_id = ?", user.ID).OrderBy("id", "desc").Find(&webhooks); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to get webhooks",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.WebhookResponse, len(webhooks))
+	for i, hook := range webhooks {
+		resp[i] = hook.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithData[[]models.WebhookResponse]{
+		Message: "Webhooks found",
+		Data:    resp,
+	})
+}
+
+// Store Register a new webhook
+// @Summary Register a new webhook
+// @Description Register a webhook URL for poll events. Deliveries are signed with
+// @Description HMAC-SHA256 in the X-Evote-Signature header ("t=<unix>,v1=<hex>").
+// @Description The secret is generated when omitted and only returned once.
+// @Description The URL must use https and resolve to public addresses only.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body requests.CreateWebhook true "Webhook data"
+// @Success 201 {object} models.ResponseWithData[models.CreateWebhookResponse] "Webhook created"
+// @Failure 400 {object} models.ErrorResponse "Validation error"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Poll not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks/create [post]
+func (r *WebhookController) Store(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Validate request
+	var request requests.CreateWebhook
+	errors, err := ctx.Request().ValidateRequest(&request)
+	if err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  err.Error(),
+		})
+	}
+	if errors != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  errors.All(),
+		})
+	}
+
+	// Check if user may manage the poll when the webhook is limited to a poll
+	var pollID *uint
+	if request.PollID != "" {
+		id, err := strconv.ParseUint(request.PollID, 10, 64)
+		if err != nil {
+			return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+				Message: "Validation error",
+				Errors:  "Invalid poll_id",
+			})
+		}
+
+		var poll models.Polls
+		if err := facades.Orm().Query().Where("id = ?", id).FirstOrFail(&poll); err != nil {
+			return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "Poll not found",
+				Errors:  "poll not found",
+			})
+		}
+		if resp := deniedResponse(ctx, "poll.update", poll); resp != nil {
+			return resp
+		}
+		pollID = &poll.ID
+	}
+
+	// Refuse URLs of internal services
+	if err := webhook.ValidateURL(request.URL); err != nil {
+		return ctx.Response().Json(http.StatusBadRequest, models.ErrorResponse{
+			Message: "Validation error",
+			Errors:  http.Json{"url": err.Error()},
+		})
+	}
+
+	// Generate secret if not provided
+	secret := request.Secret
+	if secret == "" {
+		secret, err = webhook.GenerateSecret()
+		if err != nil {
+			return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+				Message: "ups, something went wrong",
+				Errors:  err.Error(),
+			})
+		}
+	}
+
+	// Create webhook
+	hook := models.Webhooks{
+		UserID: user.ID,
+		PollID: pollID,
+		URL:    request.URL,
+		Secret: secret,
+		Events: strings.Join(request.Events, ","),
+		Active: true,
+	}
+	if err := facades.Orm().Query().Create(&hook); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "ups, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusCreated, models.ResponseWithData[models.CreateWebhookResponse]{
+		Message: "Webhook created",
+		Data: models.CreateWebhookResponse{
+			WebhookResponse: hook.ToResponse(),
+			Secret:          hook.Secret,
+		},
+	})
+}
+
+// Delete Delete a webhook
+// @Summary Delete a webhook
+// @Description Delete a webhook, pending deliveries are no longer sent
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.ResponseWithMessage "Webhook deleted"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks/{id}/delete [delete]
+func (r *WebhookController) Delete(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get webhook id
+	id := ctx.Request().Route("id")
+
+	// Delete webhook
+	result, err := facades.Orm().Query().Model(&models.Webhooks{}).Where("id = ? AND user_id = ?", id, user.ID).Delete()
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to delete webhook",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Check if any row was affected (webhook existed and user owned it)
+	if result.RowsAffected == 0 {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Webhook not found",
+			Errors:  "Webhook not found or you don't have permission to delete it",
+		})
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.ResponseWithMessage{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// Deliveries Get the delivery log of a webhook
+// @Summary Get webhook deliveries
+// @Description Get the delivery log of a webhook, newest first
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Webhook ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} models.PaginateResponse[[]models.WebhookDeliveryResponse] "Deliveries found"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Webhook not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks/{id}/deliveries [get]
+func (r *WebhookController) Deliveries(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get query params
+	id := ctx.Request().Route("id")
+	limit := ctx.Request().QueryInt("limit", 10)
+	offset := ctx.Request().QueryInt("offset", 0)
+	if limit <= 0 {
+		limit = 10
+	}
+
+	// Check if webhook exists and belongs to user
+	var hook models.Webhooks
+	if err := facades.Orm().Query().Where("id = ? AND user_id = ?", id, user.ID).FirstOrFail(&hook); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Webhook not found",
+			Errors:  "Webhook not found or you don't have permission",
+		})
+	}
+
+	// Get deliveries
+	var deliveries []models.WebhookDeliveries
+	query := facades.Orm().Query().Model(&models.WebhookDeliveries{}).Where("webhook_id = ?", hook.ID).OrderBy("id", "desc")
+	if err := query.Limit(limit).Offset(offset).Find(&deliveries); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Get total count
+	var total int64
+	if err := query.Count(&total); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Oops, something went wrong",
+			Errors:  err.Error(),
+		})
+	}
+
+	// Convert to response
+	resp := make([]models.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		resp[i] = delivery.ToResponse()
+	}
+
+	return ctx.Response().Json(http.StatusOK, models.PaginateResponse[[]models.WebhookDeliveryResponse]{
+		Message: "Deliveries found",
+		Data:    resp,
+		Meta: models.Meta{
+			Total:    int(total),
+			PerPage:  limit,
+			LastPage: int(math.Ceil(float64(total) / float64(limit))),
+			CurrPage: (offset / limit) + 1,
+		},
+	})
+}
+
+// Redeliver Send a previous delivery again
+// @Summary Redeliver a webhook delivery
+// @Description Queue a new delivery with the same payload as a previous one
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Delivery ID"
+// @Success 202 {object} models.ResponseWithMessage "Delivery queued"
+// @Failure 401 {object} models.ErrorResponse "Unauthorized"
+// @Failure 404 {object} models.ErrorResponse "Delivery not found"
+// @Failure 403 {object} models.ErrorResponse "Forbidden"
+// @Router /webhooks/deliveries/{id}/redeliver [post]
+func (r *WebhookController) Redeliver(ctx http.Context) http.Response {
+	// Get user from context
+	user, ok := users.Current(ctx)
+	if !ok {
+		return ctx.Response().Json(http.StatusUnauthorized, models.ErrorResponse{
+			Message: "Unauthorized",
+			Errors:  "Invalid token",
+		})
+	}
+
+	// Get delivery id
+	id := ctx.Request().Route("id")
+
+	// Check if delivery exists and its webhook belongs to user
+	var delivery models.WebhookDeliveries
+	if err := facades.Orm().Query().
+		Where("webhook_deliveries.id = ? AND EXISTS (SELECT 1 FROM webhooks WHERE webhooks.id = webhook_deliveries.webhook_id AND webhooks.user_id = ? AND webhooks.deleted_at IS NULL)",
+			id, user.ID).
+		FirstOrFail(&delivery); err != nil {
+		return ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+			Message: "Delivery not found",
+			Errors:  "Delivery not found or you don't have permission",
+		})
+	}
+
+	// Copy delivery and queue it
+	deliveryID, err := webhook.Redeliver(delivery)
+	if err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to redeliver",
+			Errors:  err.Error(),
+		})
+	}
+	if err := jobs.QueueWebhookDelivery(deliveryID, time.Time{}); err != nil {
+		return ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+			Message: "Failed to redeliver",
+			Errors:  err.Error(),
+		})
+	}
+
+	return ctx.Response().Json(http.StatusAccepted, models.ResponseWithMessage{
+		Message: "Delivery queued",
+	})
+}
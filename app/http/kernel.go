@@ -2,6 +2,8 @@ package http
 
 import (
 	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/http/middleware"
 )
 
 type Kernel struct {
@@ -12,5 +14,6 @@ type Kernel struct {
 func (kernel Kernel) Middleware() []http.Middleware {
 	return []http.Middleware{
 		// middleware.StartSession(),
+		middleware.SecurityHeaders(),
 	}
 }
@@ -3,14 +3,18 @@ package middleware
 import (
 	"errors"
 	"evote-be/app/models"
-	"strconv"
+	"evote-be/app/services/apikeys"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+	"strings"
 
-	"github.com/goravel/framework/auth"
 	"github.com/goravel/framework/contracts/http"
-	"github.com/goravel/framework/facades"
 )
 
-func Auth() http.Middleware {
+// Auth authenticates the request with an access token. API keys are accepted
+// too when the route names the scopes a key needs, routes without scopes are
+// for access tokens only.
+func Auth(scopes ...string) http.Middleware {
 	return func(ctx http.Context) {
 		token := ctx.Request().Header("Authorization", "")
 		if token == "" {
@@ -18,39 +22,110 @@ func Auth() http.Middleware {
 			return
 		}
 
-		payload, err := facades.Auth(ctx).Parse(token)
-		if err != nil {
-			if errors.Is(err, auth.ErrorTokenExpired) {
-				token, err = facades.Auth(ctx).Refresh()
-				if err != nil {
-					// Refresh time exceeded
-					ctx.Request().Abort(http.StatusUnauthorized)
-					return
-				}
-
-				token = "Bearer " + token
-			} else {
-				ctx.Request().Abort(http.StatusUnauthorized)
-				return
-			}
-		}
-
-		// You can get User in DB and set it to ctx
-		var user models.User
-		id, err := strconv.ParseUint(payload.Key, 10, 64)
+		accessToken := strings.TrimPrefix(token, "Bearer ")
+		if apikeys.IsKey(accessToken) {
+			authenticateKey(ctx, accessToken, scopes)
+			return
+		}
+
+		// Expired tokens are renewed at /auth/refresh
+		claims, err := tokens.Parse(ctx, token)
 		if err != nil {
 			ctx.Request().Abort(http.StatusUnauthorized)
 			return
 		}
-		user.ID = uint(id)
-		// if err := facades.Auth(ctx).User(&user); err != nil {
-		// 	ctx.Request().AbortWithStatus(http.StatusUnauthorized)
-		// 	return
-		// }
-		// ctx.WithValue("user", user)
-		ctx.WithValue("user", user)
 
-		ctx.Response().Header("Authorization", token)
+		// Reject tokens on the revocation list, e.g. after a logout
+		if tokens.IsRevoked(accessToken) {
+			ctx.Request().Abort(http.StatusUnauthorized)
+			return
+		}
+
+		// Reject tokens of revoked sessions
+		session, err := tokens.Session(accessToken)
+		if err != nil || session.RevokedAt != nil {
+			ctx.Request().Abort(http.StatusUnauthorized)
+			return
+		}
+		if session.ID != 0 {
+			tokens.Touch(session, ctx.Request().Ip())
+			ctx.WithValue("session_id", session.ID)
+		}
+
+		// Reject tokens issued until the user's tokens were invalidated, e.g. by
+		// a password reset
+		invalidatedAt, err := tokens.InvalidatedAt(claims.UserID)
+		if err != nil || tokens.Invalidated(claims.IssuedAt, invalidatedAt) {
+			ctx.Request().Abort(http.StatusUnauthorized)
+			return
+		}
+
+		if !authenticateUser(ctx, claims.UserID) {
+			return
+		}
+
 		ctx.Request().Next()
 	}
 }
+
+// authenticateKey authenticates the request with an API key that has to grant
+// all of the scopes
+func authenticateKey(ctx http.Context, plain string, scopes []string) {
+	if len(scopes) == 0 {
+		_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "API keys cannot be used for this endpoint",
+			Errors:  "API_KEY_NOT_ALLOWED",
+		}).Abort()
+		return
+	}
+
+	key, err := apikeys.Authenticate(plain)
+	if err != nil {
+		ctx.Request().Abort(http.StatusUnauthorized)
+		return
+	}
+	if !apikeys.Allows(key, scopes...) {
+		_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "The API key lacks the scope " + strings.Join(scopes, ", "),
+			Errors:  "INSUFFICIENT_SCOPE",
+		}).Abort()
+		return
+	}
+
+	if !authenticateUser(ctx, key.UserID) {
+		return
+	}
+	apikeys.Touch(key)
+	ctx.WithValue("api_key_id", key.ID)
+
+	ctx.Request().Next()
+}
+
+// authenticateUser puts the user into the context, deleted, banned and
+// unverified accounts are rejected
+func authenticateUser(ctx http.Context, userID uint) bool {
+	user, err := users.Find(userID)
+	if err == nil {
+		err = users.Check(user)
+	}
+	switch {
+	case errors.Is(err, users.ErrBanned):
+		_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "Your account has been banned",
+			Errors:  "ACCOUNT_BANNED",
+		}).Abort()
+		return false
+	case errors.Is(err, users.ErrUnverified):
+		_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+			Message: "please verify your email address",
+			Errors:  "EMAIL_NOT_VERIFIED",
+		}).Abort()
+		return false
+	case err != nil:
+		ctx.Request().Abort(http.StatusUnauthorized)
+		return false
+	}
+
+	ctx.WithValue("user", user)
+	return true
+}
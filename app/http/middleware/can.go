@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// Can rejects users the gate denies the ability, for abilities that do not
+// depend on a resource. Runs after Auth.
+func Can(ability string) http.Middleware {
+	return func(ctx http.Context) {
+		if response := facades.Gate().WithContext(ctx).Inspect(ability, map[string]any{}); !response.Allowed() {
+			_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+				Message: "Forbidden",
+				Errors:  response.Message(),
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
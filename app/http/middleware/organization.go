@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/models"
+	"evote-be/app/services/organizations"
+	"evote-be/app/services/users"
+)
+
+// Organization switches the request to the organization named by the
+// X-Organization header, its id or slug, after checking the user is a member.
+// Without the header the request works in the personal workspace of the user.
+// Runs after Auth.
+func Organization() http.Middleware {
+	return func(ctx http.Context) {
+		header := ctx.Request().Header("X-Organization", "")
+		if header == "" {
+			ctx.Request().Next()
+			return
+		}
+
+		user, ok := users.Current(ctx)
+		if !ok {
+			ctx.Request().Abort(http.StatusUnauthorized)
+			return
+		}
+
+		organization, err := organizations.Find(header)
+		if err != nil {
+			_ = ctx.Response().Json(http.StatusNotFound, models.ErrorResponse{
+				Message: "Organization not found",
+				Errors:  err.Error(),
+			}).Abort()
+			return
+		}
+
+		role, err := organizations.RoleOf(organization.ID, user.ID)
+		if err != nil || role == "" {
+			_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+				Message: "Forbidden",
+				Errors:  organizations.ErrNotMember.Error(),
+			}).Abort()
+			return
+		}
+
+		ctx.WithValue("organization", organization)
+		ctx.WithValue("organization_role", role)
+
+		ctx.Request().Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"github.com/goravel/framework/contracts/http"
+
+	"evote-be/app/models"
+	"evote-be/app/services/pow"
+)
+
+// ProofOfWork rejects requests without a solved challenge of /pow/challenge
+// in the X-Pow-Challenge and X-Pow-Nonce headers, when proof of work is on
+func ProofOfWork() http.Middleware {
+	return func(ctx http.Context) {
+		if !pow.Enabled() {
+			ctx.Request().Next()
+			return
+		}
+
+		// Guarded requests count towards the difficulty of the next challenge
+		ip := ctx.Request().Ip()
+		pow.Hit(ip)
+
+		challenge := ctx.Request().Header("X-Pow-Challenge", "")
+		nonce := ctx.Request().Header("X-Pow-Nonce", "")
+		if challenge == "" || nonce == "" {
+			_ = ctx.Response().Json(http.StatusPreconditionRequired, models.ErrorResponse{
+				Message: "Solve a challenge of /pow/challenge and send it with the X-Pow-Challenge and X-Pow-Nonce headers",
+				Errors:  "PROOF_OF_WORK_REQUIRED",
+			}).Abort()
+			return
+		}
+		if err := pow.Verify(ip, challenge, nonce); err != nil {
+			_ = ctx.Response().Json(http.StatusPreconditionRequired, models.ErrorResponse{
+				Message: err.Error(),
+				Errors:  "PROOF_OF_WORK_INVALID",
+			}).Abort()
+			return
+		}
+
+		ctx.Request().Next()
+	}
+}
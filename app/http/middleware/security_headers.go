@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+)
+
+// SecurityHeaders adds the headers of the security.headers config to every
+// response
+func SecurityHeaders() http.Middleware {
+	return func(ctx http.Context) {
+		config := facades.Config()
+
+		if maxAge := config.GetInt("security.headers.hsts.max_age"); maxAge > 0 {
+			hsts := "max-age=" + strconv.Itoa(maxAge)
+			if config.GetBool("security.headers.hsts.include_subdomains") {
+				hsts += "; includeSubDomains"
+			}
+			ctx.Response().Header("Strict-Transport-Security", hsts)
+		}
+
+		headers := map[string]string{
+			"Content-Security-Policy": config.GetString("security.headers.content_security_policy"),
+			"X-Frame-Options":         config.GetString("security.headers.frame_options"),
+			"Referrer-Policy":         config.GetString("security.headers.referrer_policy"),
+			"X-Content-Type-Options":  "nosniff",
+		}
+		for name, value := range headers {
+			if value != "" {
+				ctx.Response().Header(name, value)
+			}
+		}
+
+		ctx.Request().Next()
+	}
+}
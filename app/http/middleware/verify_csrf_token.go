@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// VerifyCsrfToken protects the form routes of the server-rendered views. Every
+// request gets a token in a cookie and the "csrf_token" context value for the
+// view, requests that change state must send it back in the form field or the
+// header.
+func VerifyCsrfToken() http.Middleware {
+	return func(ctx http.Context) {
+		config := facades.Config()
+		cookie := config.GetString("security.csrf.cookie", "XSRF-TOKEN")
+
+		token := ctx.Request().Cookie(cookie)
+		if token == "" {
+			b := make([]byte, 32)
+			if _, err := rand.Read(b); err != nil {
+				_ = ctx.Response().Json(http.StatusInternalServerError, models.ErrorResponse{
+					Message: "ups, something went wrong",
+					Errors:  err.Error(),
+				}).Abort()
+				return
+			}
+			token = hex.EncodeToString(b)
+
+			// Readable by scripts of the views to send it in the header
+			ctx.Response().Cookie(http.Cookie{
+				Name:     cookie,
+				Value:    token,
+				Path:     "/",
+				Secure:   config.GetBool("security.csrf.secure"),
+				HttpOnly: false,
+				SameSite: "strict",
+			})
+		}
+
+		switch ctx.Request().Method() {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			sent := ctx.Request().Header(config.GetString("security.csrf.header", "X-CSRF-Token"), "")
+			if sent == "" {
+				sent = ctx.Request().Input(config.GetString("security.csrf.field", "_token"))
+			}
+			if sent == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				_ = ctx.Response().Json(http.StatusForbidden, models.ErrorResponse{
+					Message: "The CSRF token is missing or invalid, reload the page and try again",
+					Errors:  "CSRF_TOKEN_MISMATCH",
+				}).Abort()
+				return
+			}
+		}
+
+		ctx.WithValue("csrf_token", token)
+		ctx.Request().Next()
+	}
+}
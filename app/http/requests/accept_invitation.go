@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type AcceptInvitation struct {
+	Token string `json:"token"`
+}
+
+func (r *AcceptInvitation) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *AcceptInvitation) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AcceptInvitation) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"token": "required|string",
+	}
+}
+
+func (r *AcceptInvitation) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AcceptInvitation) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AcceptInvitation) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type AddOrganizationMember struct {
+	Email string `json:"email" example:"jane@example.com"`
+	Role  string `json:"role" enums:"owner,admin,member"`
+}
+
+func (r *AddOrganizationMember) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *AddOrganizationMember) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AddOrganizationMember) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "required|email",
+		"role":  "required|in:owner,admin,member",
+	}
+}
+
+func (r *AddOrganizationMember) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AddOrganizationMember) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *AddOrganizationMember) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package requests
+
+import (
+	"errors"
+	"evote-be/app/services/apikeys"
+	"slices"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type CreateApiKey struct {
+	Name   string   `json:"name" example:"Results export"`
+	Scopes []string `json:"scopes" swaggertype:"array,string" enums:"polls:read,polls:write,votes:read,votes:write"`
+	// Days until the key expires, the configured default when omitted
+	ExpiresIn int `json:"expires_in" example:"90"`
+}
+
+func (r *CreateApiKey) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *CreateApiKey) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateApiKey) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"name":       "required|string|max_len:100",
+		"scopes":     "required|slice",
+		"expires_in": "int|min:1",
+	}
+}
+
+func (r *CreateApiKey) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateApiKey) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateApiKey) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	value, isExists := data.Get("scopes")
+	if !isExists {
+		return nil
+	}
+
+	scopes, ok := value.([]any)
+	if !ok || len(scopes) == 0 {
+		return errors.New("scopes must be a non-empty list")
+	}
+
+	// Check every scope is supported
+	for _, s := range scopes {
+		scope, ok := s.(string)
+		if !ok || !slices.Contains(apikeys.Scopes, scope) {
+			return apikeys.ErrInvalidScope
+		}
+	}
+
+	return nil
+}
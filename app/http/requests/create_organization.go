@@ -0,0 +1,39 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type CreateOrganization struct {
+	Name string `json:"name" example:"Human Resources"`
+	// Optional, derived from the name when empty
+	Slug string `json:"slug" example:"hr"`
+}
+
+func (r *CreateOrganization) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *CreateOrganization) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateOrganization) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"name": "required|string|max_len:255",
+		"slug": "regex:^[a-z0-9]+(-[a-z0-9]+)*$|max_len:100",
+	}
+}
+
+func (r *CreateOrganization) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateOrganization) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateOrganization) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
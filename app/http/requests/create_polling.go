@@ -14,10 +14,8 @@ type CreatePolling struct {
 	Description string     `json:"description"`
 	StartDate   *time.Time `json:"start_date" swaggertype:"string" example:"2022-01-01 00:00" format:"date-time"`
 	EndDate     time.Time  `json:"end_date" swaggertype:"string" example:"2022-01-01 00:00" format:"date-time"`
-	// testing:
-	// * active - Active, can be voted
-	// * done - Done, can't be voted
-	Status string `json:"status" swaggertype:"string" enums:"Active,Done,Scheduled"`
+	// Publish the poll right away instead of keeping it as draft
+	Publish bool `json:"publish"`
 }
 
 func (r *CreatePolling) Authorize(ctx http.Context) error {
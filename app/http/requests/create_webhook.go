@@ -0,0 +1,65 @@
+package requests
+
+import (
+	"errors"
+	"evote-be/app/models"
+	"slices"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type CreateWebhook struct {
+	URL    string   `json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `json:"events" swaggertype:"array,string" enums:"poll.started,poll.ended,vote.cast,poll.deleted"`
+	// Optional, limits the webhook to a single poll
+	PollID string `json:"poll_id"`
+}
+
+func (r *CreateWebhook) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *CreateWebhook) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateWebhook) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"url":     "required|full_url",
+		"secret":  "string|min_len:16",
+		"events":  "required|slice",
+		"poll_id": "string",
+	}
+}
+
+func (r *CreateWebhook) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateWebhook) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *CreateWebhook) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	value, isExists := data.Get("events")
+	if !isExists {
+		return nil
+	}
+
+	events, ok := value.([]any)
+	if !ok || len(events) == 0 {
+		return errors.New("events must be a non-empty list")
+	}
+
+	// Check every event is supported
+	for _, e := range events {
+		event, ok := e.(string)
+		if !ok || !slices.Contains(models.WebhookEvents, models.WebhookEvent(event)) {
+			return errors.New("invalid event, use one of poll.started, poll.ended, vote.cast, poll.deleted")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type DisableTwoFactor struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (r *DisableTwoFactor) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *DisableTwoFactor) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *DisableTwoFactor) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"password": "required|string",
+		"code":     "required|string",
+	}
+}
+
+func (r *DisableTwoFactor) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *DisableTwoFactor) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *DisableTwoFactor) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
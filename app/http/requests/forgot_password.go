@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type ForgotPassword struct {
+	Email string `json:"email"`
+}
+
+func (r *ForgotPassword) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *ForgotPassword) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ForgotPassword) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "required|email",
+	}
+}
+
+func (r *ForgotPassword) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ForgotPassword) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ForgotPassword) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type InviteCollaborator struct {
+	Email string `json:"email" example:"jane@example.com"`
+	Role  string `json:"role" enums:"owner,editor,viewer"`
+}
+
+func (r *InviteCollaborator) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *InviteCollaborator) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *InviteCollaborator) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "required|email",
+		"role":  "required|in:owner,editor,viewer",
+	}
+}
+
+func (r *InviteCollaborator) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *InviteCollaborator) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *InviteCollaborator) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
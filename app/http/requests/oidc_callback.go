@@ -0,0 +1,38 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type OIDCCallback struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+func (r *OIDCCallback) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *OIDCCallback) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *OIDCCallback) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"code":  "required|string",
+		"state": "required|string",
+	}
+}
+
+func (r *OIDCCallback) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *OIDCCallback) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *OIDCCallback) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type PasskeyLogin struct {
+	CredentialID      string `json:"credential_id"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AuthenticatorData string `json:"authenticator_data"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"user_handle"`
+}
+
+func (r *PasskeyLogin) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *PasskeyLogin) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyLogin) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"credential_id":      "required|string",
+		"client_data_json":   "required|string",
+		"authenticator_data": "required|string",
+		"signature":          "required|string",
+		"user_handle":        "string",
+	}
+}
+
+func (r *PasskeyLogin) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyLogin) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyLogin) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
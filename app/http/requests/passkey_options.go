@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type PasskeyOptions struct {
+	Email string `json:"email"`
+}
+
+func (r *PasskeyOptions) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *PasskeyOptions) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyOptions) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "email",
+	}
+}
+
+func (r *PasskeyOptions) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyOptions) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasskeyOptions) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
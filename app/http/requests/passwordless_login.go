@@ -0,0 +1,47 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type PasswordlessLogin struct {
+	Token string `json:"token"`
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+func (r *PasswordlessLogin) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *PasswordlessLogin) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessLogin) Rules(ctx http.Context) map[string]string {
+	// Links only carry the token. The rules are picked up front, required_with
+	// sees fields validated before it as present even when they were not sent.
+	if ctx.Request().Input("token") != "" {
+		return map[string]string{
+			"token": "required|string",
+		}
+	}
+
+	return map[string]string{
+		"email": "required|email",
+		"code":  "required|string|len:6",
+	}
+}
+
+func (r *PasswordlessLogin) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessLogin) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessLogin) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
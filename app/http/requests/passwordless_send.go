@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type PasswordlessSend struct {
+	Email string `json:"email"`
+}
+
+func (r *PasswordlessSend) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *PasswordlessSend) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessSend) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "required|email",
+	}
+}
+
+func (r *PasswordlessSend) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessSend) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *PasswordlessSend) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type RefreshToken struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (r *RefreshToken) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *RefreshToken) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RefreshToken) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"refresh_token": "required|string",
+	}
+}
+
+func (r *RefreshToken) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RefreshToken) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RefreshToken) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type RegisterPasskey struct {
+	Name              string `json:"name"`
+	ClientDataJSON    string `json:"client_data_json"`
+	AttestationObject string `json:"attestation_object"`
+}
+
+func (r *RegisterPasskey) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *RegisterPasskey) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RegisterPasskey) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"name":               "string|max_len:100",
+		"client_data_json":   "required|string",
+		"attestation_object": "required|string",
+	}
+}
+
+func (r *RegisterPasskey) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RegisterPasskey) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *RegisterPasskey) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
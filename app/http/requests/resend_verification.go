@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type ResendVerification struct {
+	Email string `json:"email"`
+}
+
+func (r *ResendVerification) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *ResendVerification) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResendVerification) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"email": "required|email",
+	}
+}
+
+func (r *ResendVerification) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResendVerification) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResendVerification) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type ResetPassword struct {
+	Token                string `json:"token"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+func (r *ResetPassword) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *ResetPassword) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResetPassword) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"token":                 "required|string",
+		"password":              "required|string|min_len:6",
+		"password_confirmation": "required|eq_field:password",
+	}
+}
+
+func (r *ResetPassword) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResetPassword) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ResetPassword) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
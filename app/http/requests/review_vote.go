@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type ReviewVote struct {
+	// Quarantined votes don't count towards the results
+	Status string `json:"status" example:"quarantined"`
+}
+
+func (r *ReviewVote) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *ReviewVote) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ReviewVote) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"status": "required|in:approved,quarantined",
+	}
+}
+
+func (r *ReviewVote) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ReviewVote) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *ReviewVote) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
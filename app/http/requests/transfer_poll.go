@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type TransferPoll struct {
+	// Must already be a collaborator of the poll
+	UserID uint `json:"user_id" example:"2"`
+}
+
+func (r *TransferPoll) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *TransferPoll) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransferPoll) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"user_id": "required|uint",
+	}
+}
+
+func (r *TransferPoll) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransferPoll) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransferPoll) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
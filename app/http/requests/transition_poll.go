@@ -0,0 +1,37 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type TransitionPoll struct {
+	// Recorded in the poll history
+	Reason string `json:"reason" example:"Candidate withdrew"`
+}
+
+func (r *TransitionPoll) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *TransitionPoll) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransitionPoll) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"reason": "string|max_len:255",
+	}
+}
+
+func (r *TransitionPoll) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransitionPoll) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TransitionPoll) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
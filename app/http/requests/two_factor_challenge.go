@@ -0,0 +1,41 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	// Code is the code of the authenticator app, RecoveryCode can be given instead
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+func (r *TwoFactorChallenge) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *TwoFactorChallenge) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorChallenge) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"challenge_token": "required|string",
+		"code":            "required_without:recovery_code|string",
+		"recovery_code":   "required_without:code|string",
+	}
+}
+
+func (r *TwoFactorChallenge) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorChallenge) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorChallenge) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
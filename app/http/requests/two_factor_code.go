@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type TwoFactorCode struct {
+	Code string `json:"code"`
+}
+
+func (r *TwoFactorCode) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *TwoFactorCode) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorCode) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"code": "required|string",
+	}
+}
+
+func (r *TwoFactorCode) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorCode) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *TwoFactorCode) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
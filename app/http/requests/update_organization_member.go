@@ -0,0 +1,36 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type UpdateOrganizationMember struct {
+	Role string `json:"role" enums:"owner,admin,member"`
+}
+
+func (r *UpdateOrganizationMember) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *UpdateOrganizationMember) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateOrganizationMember) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"role": "required|in:owner,admin,member",
+	}
+}
+
+func (r *UpdateOrganizationMember) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateOrganizationMember) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateOrganizationMember) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
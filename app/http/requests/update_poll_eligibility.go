@@ -0,0 +1,43 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type UpdatePollEligibility struct {
+	// Empty allows every domain
+	AllowedDomains []string `json:"allowed_domains" example:"ourcompany.com"`
+	// Requires membership of the organization, null for none
+	OrganizationID    *uint `json:"organization_id" example:"1"`
+	MinAccountAgeDays int   `json:"min_account_age_days" example:"7"`
+}
+
+func (r *UpdatePollEligibility) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *UpdatePollEligibility) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdatePollEligibility) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"allowed_domains":      "slice",
+		"allowed_domains.*":    "string|regex:^@?[a-zA-Z0-9-]+(\\.[a-zA-Z0-9-]+)+$",
+		"organization_id":      "uint",
+		"min_account_age_days": "int|min:0|max:3650",
+	}
+}
+
+func (r *UpdatePollEligibility) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdatePollEligibility) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdatePollEligibility) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
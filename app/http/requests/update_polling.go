@@ -2,7 +2,6 @@ package requests
 
 import (
 	"errors"
-	"evote-be/app/models"
 	"time"
 
 	"github.com/goravel/framework/contracts/http"
@@ -10,11 +9,12 @@ import (
 )
 
 type UpdatePolling struct {
-	Title       string        `json:"title"`
-	Description string        `json:"description"`
-	StartDate   time.Time     `json:"start_date"`
-	EndDate     time.Time     `json:"end_date"`
-	Status      models.Status `json:"status"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	// Reason is required when amending a poll whose content is locked
+	Reason string `json:"reason"`
 }
 
 func (r *UpdatePolling) Authorize(ctx http.Context) error {
@@ -31,7 +31,7 @@ func (r *UpdatePolling) Rules(ctx http.Context) map[string]string {
 		"description": "string",
 		"start_date":  "date",
 		"end_date":    "date",
-		"status":      "in:active,done",
+		"reason":      "string|max_len:255",
 	}
 }
 
@@ -65,15 +65,20 @@ func (r *UpdatePolling) PrepareForValidation(ctx http.Context, data validation.D
 		r.EndDate = endDate
 	}
 
-	// Check if start_date and end_date are valid
-	if r.StartDate.After(r.EndDate) {
+	// Check if start_date and end_date are valid when both are provided
+	if !r.StartDate.IsZero() && !r.EndDate.IsZero() && r.StartDate.After(r.EndDate) {
 		return errors.New("tanggal selesai harus setelah tanggal mulai")
 	}
 
 	// Check if start_date is in the future
-	if !r.StartDate.After(localNow) {
+	if !r.StartDate.IsZero() && !r.StartDate.After(localNow) {
 		return errors.New("tanggal mulai harus di masa depan")
 	}
 
+	// Check if end_date is in the future
+	if !r.EndDate.IsZero() && !r.EndDate.After(localNow) {
+		return errors.New("tanggal selesai harus di masa depan")
+	}
+
 	return nil
 }
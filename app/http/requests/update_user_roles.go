@@ -0,0 +1,38 @@
+package requests
+
+import (
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/contracts/validation"
+)
+
+type UpdateUserRoles struct {
+	// Replaces every role of the user
+	Roles []string `json:"roles" example:"organizer,auditor"`
+}
+
+func (r *UpdateUserRoles) Authorize(ctx http.Context) error {
+	return nil
+}
+
+func (r *UpdateUserRoles) Filters(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateUserRoles) Rules(ctx http.Context) map[string]string {
+	return map[string]string{
+		"roles":   "required|slice",
+		"roles.*": "required|string",
+	}
+}
+
+func (r *UpdateUserRoles) Messages(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateUserRoles) Attributes(ctx http.Context) map[string]string {
+	return map[string]string{}
+}
+
+func (r *UpdateUserRoles) PrepareForValidation(ctx http.Context, data validation.Data) error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/goravel/framework/contracts/queue"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/webhook"
+)
+
+type DeliverWebhook struct {
+}
+
+// Signature The name and signature of the job.
+func (receiver *DeliverWebhook) Signature() string {
+	return "deliver_webhook"
+}
+
+// Handle Execute the job.
+func (receiver *DeliverWebhook) Handle(args ...any) error {
+	if len(args) == 0 {
+		return nil
+	}
+	deliveryID, ok := args[0].(uint)
+	if !ok {
+		return nil
+	}
+
+	retryAt, err := webhook.Deliver(deliveryID)
+	if err != nil {
+		return err
+	}
+
+	// Schedule the next attempt with exponential backoff
+	if retryAt != nil {
+		return QueueWebhookDelivery(deliveryID, *retryAt)
+	}
+
+	return nil
+}
+
+// QueueWebhookDelivery pushes a delivery onto the queue, delayed until the given time if set
+func QueueWebhookDelivery(deliveryID uint, at time.Time) error {
+	task := facades.Queue().Job(&DeliverWebhook{}, []queue.Arg{
+		{Type: "uint", Value: deliveryID},
+	})
+	if !at.IsZero() {
+		task = task.Delay(at)
+	}
+	return task.Dispatch()
+}
+
+// DispatchWebhooks records and queues deliveries for a poll event. Failures are
+// only logged so that a broken subscriber setup never fails the caller.
+func DispatchWebhooks(poll models.Polls, event models.WebhookEvent, data any) {
+	ids, err := webhook.Record(poll, event, data)
+	if err != nil {
+		facades.Log().Errorf("Failed to record %s webhooks for poll %d: %v", event, poll.ID, err)
+		return
+	}
+
+	for _, id := range ids {
+		if err := QueueWebhookDelivery(id, time.Time{}); err != nil {
+			facades.Log().Errorf("Failed to queue webhook delivery %d: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/jobs"
+	"evote-be/app/models"
+	"evote-be/app/services/webhook"
+)
+
+// DispatchPollWebhooks delivers a poll event to the webhooks of the poll owner
+type DispatchPollWebhooks struct {
+	Event models.WebhookEvent
+}
+
+func (receiver *DispatchPollWebhooks) Signature() string {
+	return "dispatch_poll_webhooks:" + string(receiver.Event)
+}
+
+func (receiver *DispatchPollWebhooks) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *DispatchPollWebhooks) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+
+	// Deleted polls are still delivered
+	var poll models.Polls
+	if err := facades.Orm().Query().WithTrashed().Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
+		return err
+	}
+
+	var data any = poll.ToResponse()
+	if receiver.Event == models.VoteCastEvent {
+		optionID, _ := args[1].(uint)
+		voteID, _ := args[2].(uint)
+
+		var option models.Options
+		if err := facades.Orm().Query().Where("id = ?", optionID).FirstOrFail(&option); err != nil {
+			return err
+		}
+
+		data = webhook.VoteData{
+			PollID:     poll.ID,
+			OptionID:   option.ID,
+			VoteID:     voteID,
+			VotesCount: option.VotesCount,
+		}
+	}
+
+	jobs.DispatchWebhooks(poll, receiver.Event, data)
+
+	return nil
+}
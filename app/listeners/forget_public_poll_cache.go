@@ -0,0 +1,39 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+type ForgetPublicPollCache struct {
+}
+
+func (receiver *ForgetPublicPollCache) Signature() string {
+	return "forget_public_poll_cache"
+}
+
+func (receiver *ForgetPublicPollCache) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *ForgetPublicPollCache) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+
+	var poll models.Polls
+	if err := facades.Orm().Query().WithTrashed().Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
+		return err
+	}
+
+	// Polls without a code are never cached
+	if poll.Code != nil {
+		facades.Cache().Forget(models.PublicPollCacheKey(*poll.Code))
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+)
+
+// Isolate wraps the listeners of an event, so that a failing listener is
+// logged and the listeners after it still run. Without it the first error
+// stops the chain.
+func Isolate(listeners ...event.Listener) []event.Listener {
+	isolated := make([]event.Listener, len(listeners))
+	for i, listener := range listeners {
+		isolated[i] = &isolatedListener{Listener: listener}
+	}
+
+	return isolated
+}
+
+type isolatedListener struct {
+	event.Listener
+}
+
+func (receiver *isolatedListener) Handle(args ...any) error {
+	if err := receiver.Listener.Handle(args...); err != nil {
+		facades.Log().Errorf("Listener %s failed: %v", receiver.Signature(), err)
+	}
+
+	return nil
+}
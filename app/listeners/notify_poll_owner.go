@@ -0,0 +1,40 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+	"evote-be/app/models"
+)
+
+type NotifyPollOwner struct {
+}
+
+func (receiver *NotifyPollOwner) Signature() string {
+	return "notify_poll_owner"
+}
+
+func (receiver *NotifyPollOwner) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *NotifyPollOwner) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+
+	var poll models.Polls
+	if err := facades.Orm().Query().Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
+		return err
+	}
+
+	var owner models.User
+	if err := facades.Orm().Query().Where("id = ?", poll.UserID).FirstOrFail(&owner); err != nil {
+		return err
+	}
+
+	return facades.Mail().Queue(mails.NewPollStatusChanged(owner.Email, poll.Title, poll.Status))
+}
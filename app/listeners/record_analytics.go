@@ -0,0 +1,43 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// RecordAnalytics stores a poll event in the analytics log
+type RecordAnalytics struct {
+	Event string
+}
+
+func (receiver *RecordAnalytics) Signature() string {
+	return "record_analytics:" + receiver.Event
+}
+
+func (receiver *RecordAnalytics) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *RecordAnalytics) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+
+	record := models.AnalyticsEvents{
+		Event:  receiver.Event,
+		PollID: pollID,
+	}
+
+	// Events about an option carry its id as second argument
+	if len(args) > 1 {
+		if optionID, ok := args[1].(uint); ok {
+			record.OptionID = &optionID
+		}
+	}
+
+	return facades.Orm().Query().Create(&record)
+}
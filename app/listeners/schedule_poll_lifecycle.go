@@ -0,0 +1,43 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/lifecycle"
+)
+
+type SchedulePollLifecycle struct {
+}
+
+func (receiver *SchedulePollLifecycle) Signature() string {
+	return "schedule_poll_lifecycle"
+}
+
+func (receiver *SchedulePollLifecycle) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SchedulePollLifecycle) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+
+	var poll models.Polls
+	if err := facades.Orm().Query().Where("id = ?", pollID).First(&poll); err != nil {
+		return err
+	}
+
+	// Deleted polls have no timers left
+	if poll.ID == 0 {
+		lifecycle.Cancel(pollID)
+		return nil
+	}
+
+	lifecycle.Schedule(poll)
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package listeners
+
+import (
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+	"evote-be/app/models"
+)
+
+type SendAccountLockedEmail struct {
+}
+
+func (receiver *SendAccountLockedEmail) Signature() string {
+	return "send_account_locked_email"
+}
+
+func (receiver *SendAccountLockedEmail) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SendAccountLockedEmail) Handle(args ...any) error {
+	userID, _ := args[0].(uint)
+	ip, _ := args[1].(string)
+	duration, _ := args[2].(int)
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", userID).FirstOrFail(&user); err != nil {
+		return err
+	}
+
+	return facades.Mail().Queue(mails.NewAccountLocked(user.Email, ip, duration))
+}
@@ -0,0 +1,44 @@
+package listeners
+
+import (
+	"net/url"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+	"evote-be/app/models"
+)
+
+type SendCollaboratorInvitation struct {
+}
+
+func (receiver *SendCollaboratorInvitation) Signature() string {
+	return "send_collaborator_invitation"
+}
+
+func (receiver *SendCollaboratorInvitation) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SendCollaboratorInvitation) Handle(args ...any) error {
+	pollID, _ := args[0].(uint)
+	email, _ := args[1].(string)
+	token, _ := args[2].(string)
+	role, _ := args[3].(string)
+	inviter, _ := args[4].(string)
+
+	var poll models.Polls
+	if err := facades.Orm().Query().Where("id = ?", pollID).FirstOrFail(&poll); err != nil {
+		return err
+	}
+
+	// Link to the invitation page of the frontend
+	link := facades.Config().GetString("poll.invitations.url") + "?token=" + url.QueryEscape(token)
+
+	return facades.Mail().Queue(mails.NewCollaboratorInvitation(email, inviter, poll.Title, role, link, facades.Config().GetInt("poll.invitations.expire", 72)*60))
+}
@@ -0,0 +1,36 @@
+package listeners
+
+import (
+	"net/url"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+)
+
+type SendLoginLinkEmail struct {
+}
+
+func (receiver *SendLoginLinkEmail) Signature() string {
+	return "send_login_link_email"
+}
+
+func (receiver *SendLoginLinkEmail) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SendLoginLinkEmail) Handle(args ...any) error {
+	email, _ := args[1].(string)
+	token, _ := args[2].(string)
+	code, _ := args[3].(string)
+
+	// Link to the login page of the frontend
+	link := facades.Config().GetString("auth.passwordless.url") + "?token=" + url.QueryEscape(token)
+
+	return facades.Mail().Queue(mails.NewLoginLink(email, link, code, facades.Config().GetInt("auth.passwordless.expire", 10)))
+}
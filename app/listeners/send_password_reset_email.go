@@ -0,0 +1,35 @@
+package listeners
+
+import (
+	"net/url"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+)
+
+type SendPasswordResetEmail struct {
+}
+
+func (receiver *SendPasswordResetEmail) Signature() string {
+	return "send_password_reset_email"
+}
+
+func (receiver *SendPasswordResetEmail) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SendPasswordResetEmail) Handle(args ...any) error {
+	email, _ := args[1].(string)
+	token, _ := args[2].(string)
+
+	// Link to the reset page of the frontend
+	link := facades.Config().GetString("auth.passwords.url") + "?token=" + url.QueryEscape(token)
+
+	return facades.Mail().Queue(mails.NewPasswordReset(email, link, facades.Config().GetInt("auth.passwords.expire", 60)))
+}
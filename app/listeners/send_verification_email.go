@@ -0,0 +1,35 @@
+package listeners
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/mails"
+)
+
+type SendVerificationEmail struct {
+}
+
+func (receiver *SendVerificationEmail) Signature() string {
+	return "send_verification_email"
+}
+
+func (receiver *SendVerificationEmail) Queue(args ...any) event.Queue {
+	return event.Queue{
+		Enable:     false,
+		Connection: "",
+		Queue:      "",
+	}
+}
+
+func (receiver *SendVerificationEmail) Handle(args ...any) error {
+	email, _ := args[1].(string)
+	token, _ := args[2].(string)
+
+	// Link for email verification
+	link := fmt.Sprintf("%s/auth/verify/%s", facades.Config().GetString("APP_URL", "http://localhost:3000"), token)
+
+	return facades.Mail().Queue(mails.NewUserRegister(email, link, facades.Config().GetInt("auth.verification.expire", 1440)))
+}
@@ -0,0 +1,57 @@
+package mails
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+)
+
+type AccountLocked struct {
+	email    string
+	ip       string
+	duration int
+}
+
+func NewAccountLocked(email, ip string, duration int) *AccountLocked {
+	return &AccountLocked{
+		email:    email,
+		ip:       ip,
+		duration: duration,
+	}
+}
+
+// Attachments attach files to the mail
+func (receiver *AccountLocked) Attachments() []string {
+	return []string{}
+}
+
+// Content set the content of the mail
+func (receiver *AccountLocked) Content() *mail.Content {
+	return &mail.Content{
+		Html: fmt.Sprintf(`
+					<h1>Your account is locked</h1>
+					<p>There were too many failed attempts to login to your account, the last one from %s.</p>
+					<p>Logins with your password are blocked for %s.</p>
+					<p>If this wasn't you, please reset your password with "Forgot password" on the login page.</p>
+				`, html.EscapeString(receiver.ip), expiresIn(receiver.duration)),
+	}
+}
+
+// Envelope set the envelope of the mail
+func (receiver *AccountLocked) Envelope() *mail.Envelope {
+	return &mail.Envelope{
+		From: mail.Address{
+			Address: facades.Config().GetString("MAIL_FROM_ADDRESS", "evote@rizkirmdhn.cloud"),
+			Name:    facades.Config().GetString("MAIL_FROM_NAME", "Evote"),
+		},
+		Subject: "Your Account Is Locked",
+		To:      []string{receiver.email},
+	}
+}
+
+// Queue set the queue of the mail
+func (receiver *AccountLocked) Queue() *mail.Queue {
+	return &mail.Queue{}
+}
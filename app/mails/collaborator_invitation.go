@@ -0,0 +1,66 @@
+package mails
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+)
+
+type CollaboratorInvitation struct {
+	email   string
+	inviter string
+	poll    string
+	role    string
+	link    string
+	expire  int
+}
+
+func NewCollaboratorInvitation(email, inviter, poll, role, link string, expire int) *CollaboratorInvitation {
+	return &CollaboratorInvitation{
+		email:   email,
+		inviter: inviter,
+		poll:    poll,
+		role:    role,
+		link:    link,
+		expire:  expire,
+	}
+}
+
+// Attachments attach files to the mail
+func (receiver *CollaboratorInvitation) Attachments() []string {
+	return []string{}
+}
+
+// Content set the content of the mail
+func (receiver *CollaboratorInvitation) Content() *mail.Content {
+	return &mail.Content{
+		Html: fmt.Sprintf(`
+					<h1>Collaborate on "%s"</h1>
+					<p>%s invited you to join the poll as %s. Sign in with this email address and click the link below to accept:</p>
+					<a href="%s" style="background-color: #4CAF50; color: white; padding: 14px 20px; text-decoration: none; border-radius: 4px;">
+						Accept Invitation
+					</a>
+					<p>The invitation will expire in %s.</p>
+					<p>If you don't know this poll, please ignore this email.</p>
+				`, html.EscapeString(receiver.poll), html.EscapeString(receiver.inviter), receiver.role, receiver.link, expiresIn(receiver.expire)),
+	}
+}
+
+// Envelope set the envelope of the mail
+func (receiver *CollaboratorInvitation) Envelope() *mail.Envelope {
+	return &mail.Envelope{
+		From: mail.Address{
+			Address: facades.Config().GetString("MAIL_FROM_ADDRESS", "evote@rizkirmdhn.cloud"),
+			Name:    facades.Config().GetString("MAIL_FROM_NAME", "Evote"),
+		},
+		Subject: "Invitation to Collaborate on a Poll",
+		To:      []string{receiver.email},
+	}
+}
+
+// Queue set the queue of the mail
+func (receiver *CollaboratorInvitation) Queue() *mail.Queue {
+	return &mail.Queue{}
+}
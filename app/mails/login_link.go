@@ -0,0 +1,62 @@
+package mails
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+)
+
+type LoginLink struct {
+	email  string
+	link   string
+	code   string
+	expire int
+}
+
+func NewLoginLink(email, link, code string, expire int) *LoginLink {
+	return &LoginLink{
+		email:  email,
+		link:   link,
+		code:   code,
+		expire: expire,
+	}
+}
+
+// Attachments attach files to the mail
+func (receiver *LoginLink) Attachments() []string {
+	return []string{}
+}
+
+// Content set the content of the mail
+func (receiver *LoginLink) Content() *mail.Content {
+	return &mail.Content{
+		Html: fmt.Sprintf(`
+					<h1>Login to E-Vote</h1>
+					<p>Click the link below to login, or enter this code on the login page:</p>
+					<p style="font-size: 24px; font-weight: bold; letter-spacing: 4px;">%s</p>
+					<p>The link and code will expire in %s and can only be used once.</p>
+					<a href="%s" style="background-color: #4CAF50; color: white; padding: 14px 20px; text-decoration: none; border-radius: 4px;">
+						Login
+					</a>
+					<p>If you didn't try to login, please ignore this email.</p>
+				`, receiver.code, expiresIn(receiver.expire), receiver.link),
+	}
+}
+
+// Envelope set the envelope of the mail
+func (receiver *LoginLink) Envelope() *mail.Envelope {
+	return &mail.Envelope{
+		From: mail.Address{
+			Address: facades.Config().GetString("MAIL_FROM_ADDRESS", "evote@rizkirmdhn.cloud"),
+			Name:    facades.Config().GetString("MAIL_FROM_NAME", "Evote"),
+		},
+		Subject: "Your Login Link",
+		To:      []string{receiver.email},
+	}
+}
+
+// Queue set the queue of the mail
+func (receiver *LoginLink) Queue() *mail.Queue {
+	return &mail.Queue{}
+}
@@ -0,0 +1,59 @@
+package mails
+
+import (
+	"fmt"
+
+	"github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+)
+
+type PasswordReset struct {
+	email  string
+	link   string
+	expire int
+}
+
+func NewPasswordReset(email, link string, expire int) *PasswordReset {
+	return &PasswordReset{
+		email:  email,
+		link:   link,
+		expire: expire,
+	}
+}
+
+// Attachments attach files to the mail
+func (receiver *PasswordReset) Attachments() []string {
+	return []string{}
+}
+
+// Content set the content of the mail
+func (receiver *PasswordReset) Content() *mail.Content {
+	return &mail.Content{
+		Html: fmt.Sprintf(`
+					<h1>Reset your password</h1>
+					<p>We received a request to reset the password of your E-Vote account. Click the link below to choose a new one:</p>
+					<p>This link will expire in %s and can only be used once.</p>
+					<a href="%s" style="background-color: #4CAF50; color: white; padding: 14px 20px; text-decoration: none; border-radius: 4px;">
+						Reset Password
+					</a>
+					<p>If you didn't request a password reset, please ignore this email.</p>
+				`, expiresIn(receiver.expire), receiver.link),
+	}
+}
+
+// Envelope set the envelope of the mail
+func (receiver *PasswordReset) Envelope() *mail.Envelope {
+	return &mail.Envelope{
+		From: mail.Address{
+			Address: facades.Config().GetString("MAIL_FROM_ADDRESS", "evote@rizkirmdhn.cloud"),
+			Name:    facades.Config().GetString("MAIL_FROM_NAME", "Evote"),
+		},
+		Subject: "Password Reset Request",
+		To:      []string{receiver.email},
+	}
+}
+
+// Queue set the queue of the mail
+func (receiver *PasswordReset) Queue() *mail.Queue {
+	return &mail.Queue{}
+}
@@ -0,0 +1,63 @@
+package mails
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/goravel/framework/contracts/mail"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+type PollStatusChanged struct {
+	email  string
+	title  string
+	status models.Status
+}
+
+func NewPollStatusChanged(email, title string, status models.Status) *PollStatusChanged {
+	return &PollStatusChanged{
+		email:  email,
+		title:  title,
+		status: status,
+	}
+}
+
+// Attachments attach files to the mail
+func (receiver *PollStatusChanged) Attachments() []string {
+	return []string{}
+}
+
+// Content set the content of the mail
+func (receiver *PollStatusChanged) Content() *mail.Content {
+	message := "Voting is now open."
+	if receiver.status == models.Done {
+		message = "Voting has been closed and the results are final."
+	}
+
+	return &mail.Content{
+		Html: fmt.Sprintf(`
+					<h1>%s</h1>
+					<p>Your poll is now <strong>%s</strong>.</p>
+					<p>%s</p>
+				`, html.EscapeString(receiver.title), receiver.status, message),
+	}
+}
+
+// Envelope set the envelope of the mail
+func (receiver *PollStatusChanged) Envelope() *mail.Envelope {
+	return &mail.Envelope{
+		From: mail.Address{
+			Address: facades.Config().GetString("MAIL_FROM_ADDRESS", "evote@rizkirmdhn.cloud"),
+			Name:    facades.Config().GetString("MAIL_FROM_NAME", "Evote"),
+		},
+		Subject: fmt.Sprintf("Poll %s: %s", receiver.status, receiver.title),
+		To:      []string{receiver.email},
+	}
+}
+
+// Queue set the queue of the mail
+func (receiver *PollStatusChanged) Queue() *mail.Queue {
+	return &mail.Queue{}
+}
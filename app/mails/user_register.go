@@ -8,14 +8,16 @@ import (
 )
 
 type UserRegister struct {
-	email string
-	link  string
+	email  string
+	link   string
+	expire int
 }
 
-func NewUserRegister(email, link string) *UserRegister {
+func NewUserRegister(email, link string, expire int) *UserRegister {
 	return &UserRegister{
-		email: email,
-		link:  link,
+		email:  email,
+		link:   link,
+		expire: expire,
 	}
 }
 
@@ -30,12 +32,12 @@ func (receiver *UserRegister) Content() *mail.Content {
 		Html: fmt.Sprintf(`
 					<h1>Welcome to E-Vote!</h1>
 					<p>Please verify your email address by clicking the link below:</p>
-					<p>This link will expire in 24 hours.</p>
+					<p>This link will expire in %s.</p>
 					<a href="%s" style="background-color: #4CAF50; color: white; padding: 14px 20px; text-decoration: none; border-radius: 4px;">
 						Verify Email
 					</a>
 					<p>If you didn't create an account, please ignore this email.</p>
-				`, receiver.link),
+				`, expiresIn(receiver.expire), receiver.link),
 	}
 }
 
@@ -55,3 +57,15 @@ func (receiver *UserRegister) Envelope() *mail.Envelope {
 func (receiver *UserRegister) Queue() *mail.Queue {
 	return &mail.Queue{}
 }
+
+// expiresIn describes a lifetime given in minutes
+func expiresIn(minutes int) string {
+	switch {
+	case minutes%60 != 0:
+		return fmt.Sprintf("%d minutes", minutes)
+	case minutes == 60:
+		return "1 hour"
+	default:
+		return fmt.Sprintf("%d hours", minutes/60)
+	}
+}
@@ -0,0 +1,12 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+type AnalyticsEvents struct {
+	orm.Model
+	Event    string
+	PollID   uint
+	OptionID *uint
+}
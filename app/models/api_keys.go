@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// ApiKeys is a long-lived credential of a user for scripts and third-party
+// integrations. Only the hash of the key is stored, Prefix identifies it in
+// listings.
+type ApiKeys struct {
+	orm.Model
+	UserID     uint
+	Name       string
+	Prefix     string
+	TokenHash  string
+	Scopes     string
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+type ApiKeyResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateApiKeyResponse struct {
+	ApiKeyResponse
+	// Key is only returned once, when the API key is created
+	Key string `json:"key"`
+}
+
+// ScopeList returns the scopes granted to the key
+func (k *ApiKeys) ScopeList() []string {
+	var scopes []string
+	for _, s := range strings.Split(k.Scopes, ",") {
+		if s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	return scopes
+}
+
+func (k *ApiKeys) ToResponse() ApiKeyResponse {
+	return ApiKeyResponse{
+		ID:         int(k.ID),
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.ScopeList(),
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt.StdTime(),
+	}
+}
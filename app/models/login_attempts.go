@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// Reasons of a login attempt
+const (
+	LoginSucceeded          = "succeeded"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginEmailNotVerified   = "email_not_verified"
+	LoginLockedOut          = "locked_out"
+	LoginBanned             = "banned"
+)
+
+// LoginAttempts is the log of password logins. UserID is empty when no user
+// has the email.
+type LoginAttempts struct {
+	orm.Model
+	Email      string
+	UserID     *uint
+	IPAddress  string `gorm:"column:ip_address"`
+	UserAgent  string
+	Successful bool
+	Reason     string
+}
+
+type LoginAttemptResponse struct {
+	ID         int       `json:"id"`
+	Email      string    `json:"email"`
+	UserID     *uint     `json:"user_id"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	Successful bool      `json:"successful"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (a *LoginAttempts) ToResponse() LoginAttemptResponse {
+	return LoginAttemptResponse{
+		ID:         int(a.ID),
+		Email:      a.Email,
+		UserID:     a.UserID,
+		IPAddress:  a.IPAddress,
+		UserAgent:  a.UserAgent,
+		Successful: a.Successful,
+		Reason:     a.Reason,
+		CreatedAt:  a.CreatedAt.StdTime(),
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// LoginTokens holds the hashes of a magic link token and the code sent with it
+// for a passwordless login. Either can be used once before it expires.
+type LoginTokens struct {
+	orm.Model
+	UserID uint
+	Token  string
+	Code   string
+	// Attempts counts wrong codes, the code is discarded after too many
+	Attempts  int
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
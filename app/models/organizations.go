@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// OrganizationRole is the role of a member within an organization
+type OrganizationRole string
+
+const (
+	// OrganizationOwner manages the organization, its members and every poll in it
+	OrganizationOwner OrganizationRole = "owner"
+	// OrganizationAdmin manages the members below owner and every poll in it
+	OrganizationAdmin OrganizationRole = "admin"
+	// OrganizationMember creates polls and sees every poll in it
+	OrganizationMember OrganizationRole = "member"
+)
+
+// CanManage reports whether the role allows managing members and every poll
+func (r OrganizationRole) CanManage() bool {
+	return r == OrganizationOwner || r == OrganizationAdmin
+}
+
+// Organizations separate the polls and members of departments. Polls without
+// an organization belong to the personal workspace of their owner.
+type Organizations struct {
+	orm.Model
+	Name string
+	Slug string
+}
+
+type OrganizationMembers struct {
+	orm.Model
+	OrganizationID uint
+	UserID         uint
+	Role           OrganizationRole
+}
+
+type OrganizationResponse struct {
+	ID        int              `json:"id"`
+	Name      string           `json:"name"`
+	Slug      string           `json:"slug"`
+	Role      OrganizationRole `json:"role"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type OrganizationMemberResponse struct {
+	UserID int              `json:"user_id"`
+	Name   string           `json:"name"`
+	Email  string           `json:"email"`
+	Role   OrganizationRole `json:"role"`
+}
+
+func (o *Organizations) ToResponse(role OrganizationRole) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        int(o.ID),
+		Name:      o.Name,
+		Slug:      o.Slug,
+		Role:      role,
+		CreatedAt: o.CreatedAt.StdTime(),
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// PasskeyCredentials is a passkey a user registered to login without a
+// password. CredentialID and PublicKey are base64url encoded, the public key
+// in COSE format.
+type PasskeyCredentials struct {
+	orm.Model
+	UserID       uint
+	CredentialID string
+	PublicKey    string
+	SignCount    uint32
+	Name         string
+	LastUsedAt   *time.Time
+}
+
+type PasskeyCredentialResponse struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
+
+func (p *PasskeyCredentials) ToResponse() PasskeyCredentialResponse {
+	return PasskeyCredentialResponse{
+		ID:         int(p.ID),
+		Name:       p.Name,
+		CreatedAt:  p.CreatedAt.StdTime(),
+		LastUsedAt: p.LastUsedAt,
+	}
+}
+
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PasskeyUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type PasskeyCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type PasskeyCredentialDescriptor struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyCreationOptions are the options for navigator.credentials.create(),
+// binary values are base64url encoded
+type PasskeyCreationOptions struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUser                   `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int                           `json:"timeout"`
+	Attestation            string                        `json:"attestation"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+}
+
+// PasskeyRequestOptions are the options for navigator.credentials.get(),
+// binary values are base64url encoded
+type PasskeyRequestOptions struct {
+	Challenge        string                        `json:"challenge"`
+	RPID             string                        `json:"rpId"`
+	Timeout          int                           `json:"timeout"`
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                        `json:"userVerification"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// PasswordResets holds the hash of a reset token, it can be used once before it expires
+type PasswordResets struct {
+	orm.Model
+	UserID    uint
+	Token     string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// PollAmendments records a change made to a poll after its content was locked
+type PollAmendments struct {
+	orm.Model
+	PollID   uint
+	Field    string
+	OldValue string
+	NewValue string
+	Reason   string
+	UserID   uint
+}
+
+type PollAmendmentResponse struct {
+	ID        int       `json:"id"`
+	Field     string    `json:"field"`
+	OldValue  string    `json:"old_value"`
+	NewValue  string    `json:"new_value"`
+	Reason    string    `json:"reason"`
+	UserID    uint      `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (a *PollAmendments) ToResponse() PollAmendmentResponse {
+	return PollAmendmentResponse{
+		ID:        int(a.ID),
+		Field:     a.Field,
+		OldValue:  a.OldValue,
+		NewValue:  a.NewValue,
+		Reason:    a.Reason,
+		UserID:    a.UserID,
+		CreatedAt: a.CreatedAt.StdTime(),
+	}
+}
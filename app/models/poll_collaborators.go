@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// CollaboratorRole is the grant a user has on a poll
+type CollaboratorRole string
+
+const (
+	// CollaboratorOwner manages the poll, its collaborators and can delete it
+	CollaboratorOwner CollaboratorRole = "owner"
+	// CollaboratorEditor edits the poll and its options
+	CollaboratorEditor CollaboratorRole = "editor"
+	// CollaboratorViewer sees the poll, its options and results
+	CollaboratorViewer CollaboratorRole = "viewer"
+)
+
+// CanEdit reports whether the grant allows editing the poll and its options
+func (r CollaboratorRole) CanEdit() bool {
+	return r == CollaboratorOwner || r == CollaboratorEditor
+}
+
+// PollCollaborators grants users other than the creator access to a poll. The
+// creator, Polls.UserID, is the primary owner and has no row.
+type PollCollaborators struct {
+	orm.Model
+	PollID uint
+	UserID uint
+	Role   CollaboratorRole
+}
+
+// PollInvitations are emailed invitations to collaborate on a poll, the token
+// is stored hashed
+type PollInvitations struct {
+	orm.Model
+	PollID     uint
+	Email      string
+	Role       CollaboratorRole
+	Token      string
+	InvitedBy  uint
+	ExpiresAt  time.Time
+	AcceptedAt *time.Time
+}
+
+type PollCollaboratorResponse struct {
+	UserID int              `json:"user_id"`
+	Name   string           `json:"name"`
+	Email  string           `json:"email"`
+	Role   CollaboratorRole `json:"role"`
+}
+
+type PollInvitationResponse struct {
+	ID        int              `json:"id"`
+	PollID    int              `json:"poll_id"`
+	Email     string           `json:"email"`
+	Role      CollaboratorRole `json:"role"`
+	ExpiresAt time.Time        `json:"expires_at"`
+}
+
+func (i *PollInvitations) ToResponse() PollInvitationResponse {
+	return PollInvitationResponse{
+		ID:        int(i.ID),
+		PollID:    int(i.PollID),
+		Email:     i.Email,
+		Role:      i.Role,
+		ExpiresAt: i.ExpiresAt,
+	}
+}
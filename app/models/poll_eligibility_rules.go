@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// PollEligibilityRules restrict who may vote in a poll, every rule that is set
+// must be met. A poll without rules is open to every signed in user, whose
+// email is always verified.
+type PollEligibilityRules struct {
+	orm.Model
+	PollID uint
+	// AllowedDomains is a comma-separated list of email domains, e.g.
+	// "ourcompany.com,ourcompany.co.id"
+	AllowedDomains string
+	// OrganizationID requires membership of the organization
+	OrganizationID *uint
+	// MinAccountAgeDays requires accounts to be at least this many days old
+	MinAccountAgeDays int
+}
+
+type PollEligibilityResponse struct {
+	AllowedDomains    []string `json:"allowed_domains"`
+	OrganizationID    *uint    `json:"organization_id"`
+	MinAccountAgeDays int      `json:"min_account_age_days"`
+}
+
+// Domains returns the allowed email domains, empty when every domain is allowed
+func (r *PollEligibilityRules) Domains() []string {
+	domains := []string{}
+	for _, domain := range strings.Split(r.AllowedDomains, ",") {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			domains = append(domains, domain)
+		}
+	}
+
+	return domains
+}
+
+func (r *PollEligibilityRules) ToResponse() PollEligibilityResponse {
+	return PollEligibilityResponse{
+		AllowedDomains:    r.Domains(),
+		OrganizationID:    r.OrganizationID,
+		MinAccountAgeDays: r.MinAccountAgeDays,
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+type PollTransitions struct {
+	orm.Model
+	PollID     uint
+	FromStatus Status
+	ToStatus   Status
+	Reason     string
+	// UserID is empty for transitions made by the scheduler
+	UserID *uint
+}
+
+type PollTransitionResponse struct {
+	ID        int       `json:"id"`
+	From      Status    `json:"from"`
+	To        Status    `json:"to"`
+	Reason    string    `json:"reason"`
+	UserID    *uint     `json:"user_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *PollTransitions) ToResponse() PollTransitionResponse {
+	return PollTransitionResponse{
+		ID:        int(t.ID),
+		From:      t.FromStatus,
+		To:        t.ToStatus,
+		Reason:    t.Reason,
+		UserID:    t.UserID,
+		CreatedAt: t.CreatedAt.StdTime(),
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/goravel/framework/database/orm"
@@ -10,11 +11,42 @@ import (
 type Status string
 
 const (
+	Draft     Status = "Draft"
+	Scheduled Status = "Scheduled"
 	Active    Status = "Active"
+	Paused    Status = "Paused"
 	Done      Status = "Done"
-	Scheduled Status = "Scheduled"
+	Cancelled Status = "Cancelled"
+	Archived  Status = "Archived"
 )
 
+// statusTransitions Allowed transitions of the poll state machine
+var statusTransitions = map[Status][]Status{
+	Draft:     {Scheduled, Active, Cancelled},
+	Scheduled: {Draft, Active, Cancelled},
+	Active:    {Paused, Done, Cancelled},
+	Paused:    {Active, Done, Cancelled},
+	Done:      {Archived},
+	Cancelled: {Archived},
+	Archived:  {},
+}
+
+// CanTransitionTo reports whether a poll in this status may move to the given one
+func (s Status) CanTransitionTo(to Status) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+// IsFinal reports whether voting on a poll in this status is over for good
+func (s Status) IsFinal() bool {
+	return s == Done || s == Cancelled || s == Archived
+}
+
+// LocksContent reports whether voting has begun on a poll in this status, after
+// which its options and dates can no longer be changed freely
+func (s Status) LocksContent() bool {
+	return s != Draft && s != Scheduled
+}
+
 type Polls struct {
 	orm.Model
 	Title       string
@@ -24,8 +56,10 @@ type Polls struct {
 	EndDate     time.Time
 	Code        *string
 	UserID      uint
-	Options     []*Options `gorm:"foreignKey:PollID"`
-	Votes       []*Votes   `gorm:"foreignKey:PollID"`
+	// OrganizationID is nil for polls in the personal workspace of the owner
+	OrganizationID *uint
+	Options        []*Options `gorm:"foreignKey:PollID"`
+	Votes          []*Votes   `gorm:"foreignKey:PollID"`
 	orm.SoftDeletes
 }
 
@@ -37,6 +71,8 @@ type CreatePollingResponse struct {
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
 	Code        string    `json:"code"`
+	// PublishError tells why a poll created with publish stayed a draft
+	PublishError string `json:"publish_error,omitempty"`
 }
 
 type PollsResponse struct {
@@ -47,6 +83,8 @@ type PollsResponse struct {
 	StartDate   string  `json:"start_date"`
 	EndDate     string  `json:"end_date"`
 	Code        *string `json:"code,omitempty"`
+	// OrganizationID is omitted for polls in a personal workspace
+	OrganizationID *uint `json:"organization_id,omitempty"`
 }
 
 type UpdatePollingResponse struct {
@@ -70,15 +108,21 @@ type PublicPollsResponse struct {
 	Options     []CreateOptionsResponse `json:"options,omitempty"`
 }
 
+// PublicPollCacheKey is the cache key of the public response of a poll
+func PublicPollCacheKey(code string) string {
+	return "polls:public:" + code
+}
+
 func (p *Polls) ToResponse() PollsResponse {
 	return PollsResponse{
-		ID:          int(p.ID),
-		Title:       p.Title,
-		Description: p.Description,
-		Status:      p.Status,
-		StartDate:   p.StartDate.String(),
-		EndDate:     p.EndDate.String(),
-		Code:        p.Code,
+		ID:             int(p.ID),
+		Title:          p.Title,
+		Description:    p.Description,
+		Status:         p.Status,
+		StartDate:      p.StartDate.String(),
+		EndDate:        p.EndDate.String(),
+		Code:           p.Code,
+		OrganizationID: p.OrganizationID,
 	}
 }
 
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// RefreshTokens holds the hash of a refresh token. Tokens rotated from the same
+// login share a family, which is revoked as a whole when a used token comes back.
+type RefreshTokens struct {
+	orm.Model
+	UserID   uint
+	FamilyID string
+	Token    string
+	// AccessToken is the hash of the access token issued together with this one
+	AccessToken     string
+	AccessExpiresAt time.Time
+	ExpiresAt       time.Time
+	UsedAt          *time.Time
+	RevokedAt       *time.Time
+}
+
+type TokenResponse struct {
+	Token        string    `json:"token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// Roles group permissions, users get the permissions of their roles
+type Roles struct {
+	orm.Model
+	Name        string
+	Description string
+}
+
+// Permissions are the actions a role allows, e.g. "poll.update"
+type Permissions struct {
+	orm.Model
+	Name        string
+	Description string
+}
+
+type RolePermissions struct {
+	RoleID       uint
+	PermissionID uint
+}
+
+type UserRoles struct {
+	UserID uint
+	RoleID uint
+}
+
+type RoleResponse struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type UserRolesResponse struct {
+	UserID int      `json:"user_id"`
+	Roles  []string `json:"roles"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// SigningKeys are the keys access tokens are signed with. The newest key that
+// is not retired signs, retired keys still verify the tokens they signed until
+// those expired.
+type SigningKeys struct {
+	orm.Model
+	Kid       string
+	Algorithm string
+	// PrivateKey is the PKCS #8 PEM of the key, encrypted with the app key
+	PrivateKey string
+	PublicKey  string
+	RetiredAt  *time.Time
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// TwoFactorRecoveryCodes holds the hash of a one-time code that replaces the
+// authenticator app when it is lost
+type TwoFactorRecoveryCodes struct {
+	orm.Model
+	UserID uint
+	Code   string
+	UsedAt *time.Time
+}
+
+type TwoFactorSetupResponse struct {
+	Secret string `json:"secret"`
+	// URI is the otpauth URI to show as a QR code
+	URI string `json:"uri"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
@@ -1,19 +1,35 @@
 package models
 
 import (
+	"time"
+
 	"github.com/goravel/framework/database/orm"
 )
 
 type User struct {
 	orm.Model
-	Name              string
-	Email             string
-	Password          string
-	Avatar            string
-	EmailVerifiedAt   string
-	VerificationToken string
-	TokenExpiresAt    string
-	Polls             []*Polls
+	Name            string
+	Email           string
+	Password        string
+	Avatar          string
+	EmailVerifiedAt *time.Time
+	// VerificationToken is the hash of the token sent in the verification email
+	VerificationToken     string
+	VerificationExpiresAt *time.Time
+	VerificationSentAt    *time.Time
+	// TokensValidAfter rejects every token issued before it, e.g. after a password reset
+	TokensValidAfter *time.Time
+	// TwoFactorSecret is encrypted with the app key, two-factor authentication
+	// is on once TwoFactorConfirmedAt is set
+	TwoFactorSecret      string
+	TwoFactorConfirmedAt *time.Time
+	// TwoFactorLastStep is the time step of the last accepted code
+	TwoFactorLastStep int64
+	// BannedAt is set while the account is banned, it can no longer sign in
+	// and its tokens are rejected
+	BannedAt  *time.Time
+	BanReason string
+	Polls     []*Polls
 	orm.SoftDeletes
 }
 
@@ -30,4 +46,7 @@ type UserLoginResponse struct {
 	Email  string `json:"email"`
 	Avatar string `json:"avatar"`
 	Token  string `json:"token"`
+	// RefreshToken is exchanged for a new pair at /auth/refresh once Token expired
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
 }
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/goravel/framework/database/orm"
+)
+
+// UserIdentities links the account of a user at a login provider to the user
+type UserIdentities struct {
+	orm.Model
+	UserID   uint
+	Provider string
+	// Subject is the id of the account at the provider
+	Subject string
+	Email   string
+}
+
+type OIDCRedirectResponse struct {
+	// URL is the page of the provider the user is sent to
+	URL   string `json:"url"`
+	State string `json:"state"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// UserSessions is a login of a user on a device. The refresh tokens rotated
+// from the login share its family.
+type UserSessions struct {
+	orm.Model
+	UserID     uint
+	FamilyID   string
+	UserAgent  string
+	IPAddress  string `gorm:"column:ip_address"`
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+type UserSessionResponse struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	Current    bool      `json:"current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+func (s *UserSessions) ToResponse(currentID uint) UserSessionResponse {
+	return UserSessionResponse{
+		ID:         int(s.ID),
+		UserAgent:  s.UserAgent,
+		IPAddress:  s.IPAddress,
+		Current:    s.ID == currentID,
+		CreatedAt:  s.CreatedAt.StdTime(),
+		LastSeenAt: s.LastSeenAt,
+	}
+}
@@ -1,14 +1,78 @@
 package models
 
 import (
+	"strings"
+	"time"
+
 	"github.com/goravel/framework/database/orm"
 )
 
+// Review statuses of a vote, votes that were not reviewed have none
+const (
+	VoteApproved    = "approved"
+	VoteQuarantined = "quarantined"
+)
+
 type Votes struct {
 	orm.Model
 	UserID   uint
 	PollID   uint
 	OptionID uint
 	Polls    Polls `gorm:"foreignKey:PollID"`
+	// IPHash and Fingerprint are keyed hashes of the client IP and the
+	// optional device fingerprint the vote was cast with
+	IPHash      string `gorm:"column:ip_hash"`
+	UserAgent   string
+	Fingerprint string
+	RiskScore   int
+	// RiskSignals is a comma-separated list of the heuristics that matched
+	RiskSignals  string
+	Flagged      bool
+	ReviewStatus string
+	ReviewedAt   *time.Time
 	orm.SoftDeletes
 }
+
+type VoteReviewResponse struct {
+	ID           int        `json:"id"`
+	UserID       uint       `json:"user_id"`
+	OptionID     uint       `json:"option_id"`
+	IPHash       string     `json:"ip_hash"`
+	UserAgent    string     `json:"user_agent"`
+	Fingerprint  string     `json:"fingerprint"`
+	RiskScore    int        `json:"risk_score"`
+	RiskSignals  []string   `json:"risk_signals"`
+	Flagged      bool       `json:"flagged"`
+	ReviewStatus string     `json:"review_status"`
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// Signals returns the heuristics that matched when the vote was cast
+func (v *Votes) Signals() []string {
+	signals := []string{}
+	for _, signal := range strings.Split(v.RiskSignals, ",") {
+		if signal != "" {
+			signals = append(signals, signal)
+		}
+	}
+
+	return signals
+}
+
+func (v *Votes) ToReviewResponse() VoteReviewResponse {
+	return VoteReviewResponse{
+		ID:           int(v.ID),
+		UserID:       v.UserID,
+		OptionID:     v.OptionID,
+		IPHash:       v.IPHash,
+		UserAgent:    v.UserAgent,
+		Fingerprint:  v.Fingerprint,
+		RiskScore:    v.RiskScore,
+		RiskSignals:  v.Signals(),
+		Flagged:      v.Flagged,
+		ReviewStatus: v.ReviewStatus,
+		ReviewedAt:   v.ReviewedAt,
+		CreatedAt:    v.CreatedAt.StdTime(),
+	}
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+)
+
+// WebhookEvent Poll event type delivered to webhooks
+type WebhookEvent string
+
+const (
+	PollStartedEvent WebhookEvent = "poll.started"
+	PollEndedEvent   WebhookEvent = "poll.ended"
+	VoteCastEvent    WebhookEvent = "vote.cast"
+	PollDeletedEvent WebhookEvent = "poll.deleted"
+)
+
+// WebhookEvents All events a webhook can subscribe to
+var WebhookEvents = []WebhookEvent{PollStartedEvent, PollEndedEvent, VoteCastEvent, PollDeletedEvent}
+
+// DeliveryStatus Webhook delivery enum type
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "Pending"
+	DeliverySucceeded DeliveryStatus = "Succeeded"
+	DeliveryFailed    DeliveryStatus = "Failed"
+)
+
+type Webhooks struct {
+	orm.Model
+	UserID     uint
+	PollID     *uint
+	URL        string `gorm:"column:url"`
+	Secret     string
+	Events     string
+	Active     bool
+	Deliveries []*WebhookDeliveries `gorm:"foreignKey:WebhookID"`
+	orm.SoftDeletes
+}
+
+type WebhookDeliveries struct {
+	orm.Model
+	WebhookID     uint
+	Event         WebhookEvent
+	Payload       string
+	Status        DeliveryStatus
+	Attempts      uint
+	ResponseCode  int
+	ResponseBody  string
+	Error         string
+	NextAttemptAt *time.Time
+	DeliveredAt   *time.Time
+	Webhook       Webhooks `gorm:"foreignKey:WebhookID"`
+}
+
+type WebhookResponse struct {
+	ID        int            `json:"id"`
+	PollID    *uint          `json:"poll_id,omitempty"`
+	URL       string         `json:"url"`
+	Events    []WebhookEvent `json:"events"`
+	Active    bool           `json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type CreateWebhookResponse struct {
+	WebhookResponse
+	// Secret is only returned once, when the webhook is created
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            int            `json:"id"`
+	Event         WebhookEvent   `json:"event"`
+	Status        DeliveryStatus `json:"status"`
+	Attempts      uint           `json:"attempts"`
+	ResponseCode  int            `json:"response_code"`
+	Error         string         `json:"error,omitempty"`
+	NextAttemptAt *time.Time     `json:"next_attempt_at,omitempty"`
+	DeliveredAt   *time.Time     `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+// EventList returns the subscribed events
+func (w *Webhooks) EventList() []WebhookEvent {
+	var events []WebhookEvent
+	for _, e := range strings.Split(w.Events, ",") {
+		if e != "" {
+			events = append(events, WebhookEvent(e))
+		}
+	}
+	return events
+}
+
+// Subscribes reports whether the webhook listens to the given event
+func (w *Webhooks) Subscribes(event WebhookEvent) bool {
+	return slices.Contains(w.EventList(), event)
+}
+
+func (w *Webhooks) ToResponse() WebhookResponse {
+	return WebhookResponse{
+		ID:        int(w.ID),
+		PollID:    w.PollID,
+		URL:       w.URL,
+		Events:    w.EventList(),
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt.StdTime(),
+	}
+}
+
+func (d *WebhookDeliveries) ToResponse() WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:            int(d.ID),
+		Event:         d.Event,
+		Status:        d.Status,
+		Attempts:      d.Attempts,
+		ResponseCode:  d.ResponseCode,
+		Error:         d.Error,
+		NextAttemptAt: d.NextAttemptAt,
+		DeliveredAt:   d.DeliveredAt,
+		CreatedAt:     d.CreatedAt.StdTime(),
+	}
+}
@@ -0,0 +1,24 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/goravel/framework/auth/access"
+	contractsaccess "github.com/goravel/framework/contracts/auth/access"
+
+	"evote-be/app/services/rbac"
+	"evote-be/app/services/users"
+)
+
+// Permission returns an ability that is allowed when the roles of the current
+// user grant the permission
+func Permission(permission string) func(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	return func(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+		user, ok := users.Current(ctx)
+		if ok && rbac.Can(user.ID, permission) {
+			return access.NewAllowResponse()
+		}
+
+		return access.NewDenyResponse("Missing permission " + permission)
+	}
+}
@@ -0,0 +1,115 @@
+package policies
+
+import (
+	"context"
+
+	"github.com/goravel/framework/auth/access"
+	contractsaccess "github.com/goravel/framework/contracts/auth/access"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/collaborators"
+	"evote-be/app/services/rbac"
+	"evote-be/app/services/users"
+)
+
+type PollPolicy struct {
+}
+
+func NewPollPolicy() *PollPolicy {
+	return &PollPolicy{}
+}
+
+// View allows users with any grant on the poll and users who may view any poll
+func (r *PollPolicy) View(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	user, _, grant, ok := userAndGrant(ctx, arguments)
+	if !ok {
+		return access.NewDenyResponse("poll not found")
+	}
+	if grant != "" || rbac.Can(user.ID, rbac.PollViewAny) {
+		return access.NewAllowResponse()
+	}
+
+	return access.NewDenyResponse("You are not allowed to view this poll")
+}
+
+// Create allows users whose roles may create polls
+func (r *PollPolicy) Create(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	return Permission(rbac.PollCreate)(ctx, arguments)
+}
+
+// Update allows owners and editors who may edit their polls and users who may
+// edit any poll
+func (r *PollPolicy) Update(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	user, _, grant, ok := userAndGrant(ctx, arguments)
+	if !ok {
+		return access.NewDenyResponse("poll not found")
+	}
+	if (grant.CanEdit() && rbac.Can(user.ID, rbac.PollUpdate)) || rbac.Can(user.ID, rbac.PollUpdateAny) {
+		return access.NewAllowResponse()
+	}
+
+	return access.NewDenyResponse("You are not allowed to manage this poll")
+}
+
+// Delete allows owners who may delete their polls and users who may delete any poll
+func (r *PollPolicy) Delete(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	user, _, grant, ok := userAndGrant(ctx, arguments)
+	if !ok {
+		return access.NewDenyResponse("poll not found")
+	}
+	if (grant == models.CollaboratorOwner && rbac.Can(user.ID, rbac.PollDelete)) || rbac.Can(user.ID, rbac.PollDeleteAny) {
+		return access.NewAllowResponse()
+	}
+
+	return access.NewDenyResponse("You are not allowed to delete this poll")
+}
+
+// ManageCollaborators allows owners who may edit their polls and users who may
+// edit any poll to invite and remove collaborators
+func (r *PollPolicy) ManageCollaborators(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	user, _, grant, ok := userAndGrant(ctx, arguments)
+	if !ok {
+		return access.NewDenyResponse("poll not found")
+	}
+	if (grant == models.CollaboratorOwner && rbac.Can(user.ID, rbac.PollUpdate)) || rbac.Can(user.ID, rbac.PollUpdateAny) {
+		return access.NewAllowResponse()
+	}
+
+	return access.NewDenyResponse("You are not allowed to manage the collaborators of this poll")
+}
+
+// Transfer allows the primary owner and users who may edit any poll to hand
+// the poll over to a collaborator
+func (r *PollPolicy) Transfer(ctx context.Context, arguments map[string]any) contractsaccess.Response {
+	user, poll, _, ok := userAndGrant(ctx, arguments)
+	if !ok {
+		return access.NewDenyResponse("poll not found")
+	}
+	if poll.UserID == user.ID || rbac.Can(user.ID, rbac.PollUpdateAny) {
+		return access.NewAllowResponse()
+	}
+
+	return access.NewDenyResponse("Only the owner can transfer this poll")
+}
+
+// userAndGrant returns the current user, the poll of the arguments and the
+// grant of the user on it
+func userAndGrant(ctx context.Context, arguments map[string]any) (models.User, models.Polls, models.CollaboratorRole, bool) {
+	user, ok := users.Current(ctx)
+	if !ok {
+		return models.User{}, models.Polls{}, "", false
+	}
+	poll, ok := arguments["poll"].(models.Polls)
+	if !ok {
+		return models.User{}, models.Polls{}, "", false
+	}
+
+	grant, err := collaborators.RoleOf(poll, user.ID)
+	if err != nil {
+		facades.Log().Errorf("Failed to get grant of user %d on poll %d: %v", user.ID, poll.ID, err)
+		return models.User{}, models.Polls{}, "", false
+	}
+
+	return user, poll, grant, true
+}
@@ -2,6 +2,10 @@ package providers
 
 import (
 	"github.com/goravel/framework/contracts/foundation"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/policies"
+	"evote-be/app/services/rbac"
 )
 
 type AuthServiceProvider struct {
@@ -12,5 +16,17 @@ func (receiver *AuthServiceProvider) Register(app foundation.Application) {
 }
 
 func (receiver *AuthServiceProvider) Boot(app foundation.Application) {
+	// Abilities on a poll take it as the "poll" argument
+	pollPolicy := policies.NewPollPolicy()
+	facades.Gate().Define("poll.view", pollPolicy.View)
+	facades.Gate().Define("poll.create", pollPolicy.Create)
+	facades.Gate().Define("poll.update", pollPolicy.Update)
+	facades.Gate().Define("poll.delete", pollPolicy.Delete)
+	facades.Gate().Define("poll.collaborators", pollPolicy.ManageCollaborators)
+	facades.Gate().Define("poll.transfer", pollPolicy.Transfer)
 
+	facades.Gate().Define("vote.cast", policies.Permission(rbac.VoteCast))
+	facades.Gate().Define("webhook.manage", policies.Permission(rbac.WebhookManage))
+	facades.Gate().Define("role.manage", policies.Permission(rbac.RoleManage))
+	facades.Gate().Define("login_attempt.view", policies.Permission(rbac.LoginAttemptView))
 }
@@ -4,6 +4,10 @@ import (
 	"github.com/goravel/framework/contracts/event"
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/facades"
+
+	"evote-be/app/events"
+	"evote-be/app/listeners"
+	"evote-be/app/models"
 )
 
 type EventServiceProvider struct {
@@ -18,5 +22,66 @@ func (receiver *EventServiceProvider) Boot(app foundation.Application) {
 }
 
 func (receiver *EventServiceProvider) listen() map[event.Event][]event.Listener {
-	return map[event.Event][]event.Listener{}
+	return map[event.Event][]event.Listener{
+		&events.UserRegistered{}: {
+			&listeners.SendVerificationEmail{},
+		},
+		&events.VerificationRequested{}: {
+			&listeners.SendVerificationEmail{},
+		},
+		&events.PasswordResetRequested{}: {
+			&listeners.SendPasswordResetEmail{},
+		},
+		&events.LoginLinkRequested{}: {
+			&listeners.SendLoginLinkEmail{},
+		},
+		&events.AccountLocked{}: {
+			&listeners.SendAccountLockedEmail{},
+		},
+		&events.CollaboratorInvited{}: {
+			&listeners.SendCollaboratorInvitation{},
+		},
+		&events.PollCreated{}: listeners.Isolate(
+			&listeners.SchedulePollLifecycle{},
+			&listeners.RecordAnalytics{Event: "poll_created"},
+		),
+		&events.PollUpdated{}: listeners.Isolate(
+			&listeners.SchedulePollLifecycle{},
+			&listeners.ForgetPublicPollCache{},
+		),
+		&events.PollStatusChanged{}: listeners.Isolate(
+			&listeners.SchedulePollLifecycle{},
+			&listeners.ForgetPublicPollCache{},
+			&listeners.RecordAnalytics{Event: "poll_status_changed"},
+		),
+		&events.PollStarted{}: listeners.Isolate(
+			&listeners.DispatchPollWebhooks{Event: models.PollStartedEvent},
+			&listeners.NotifyPollOwner{},
+			&listeners.RecordAnalytics{Event: "poll_started"},
+		),
+		&events.PollEnded{}: listeners.Isolate(
+			&listeners.DispatchPollWebhooks{Event: models.PollEndedEvent},
+			&listeners.NotifyPollOwner{},
+			&listeners.RecordAnalytics{Event: "poll_ended"},
+		),
+		&events.PollDeleted{}: listeners.Isolate(
+			&listeners.SchedulePollLifecycle{},
+			&listeners.ForgetPublicPollCache{},
+			&listeners.DispatchPollWebhooks{Event: models.PollDeletedEvent},
+			&listeners.RecordAnalytics{Event: "poll_deleted"},
+		),
+		&events.VoteCast{}: listeners.Isolate(
+			&listeners.ForgetPublicPollCache{},
+			&listeners.DispatchPollWebhooks{Event: models.VoteCastEvent},
+			&listeners.RecordAnalytics{Event: "vote_cast"},
+		),
+		&events.VoteReviewed{}: listeners.Isolate(
+			&listeners.ForgetPublicPollCache{},
+			&listeners.RecordAnalytics{Event: "vote_reviewed"},
+		),
+		&events.OptionChanged{}: listeners.Isolate(
+			&listeners.ForgetPublicPollCache{},
+			&listeners.RecordAnalytics{Event: "option_changed"},
+		),
+	}
 }
@@ -4,6 +4,8 @@ import (
 	"github.com/goravel/framework/contracts/foundation"
 	"github.com/goravel/framework/contracts/queue"
 	"github.com/goravel/framework/facades"
+
+	"evote-be/app/jobs"
 )
 
 type QueueServiceProvider struct {
@@ -18,5 +20,7 @@ func (receiver *QueueServiceProvider) Boot(app foundation.Application) {
 }
 
 func (receiver *QueueServiceProvider) Jobs() []queue.Job {
-	return []queue.Job{}
+	return []queue.Job{
+		&jobs.DeliverWebhook{},
+	}
 }
@@ -1,10 +1,17 @@
 package providers
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/goravel/framework/contracts/foundation"
+	contractshttp "github.com/goravel/framework/contracts/http"
 	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/http/limit"
 
 	"evote-be/app/http"
+	"evote-be/app/models"
+	"evote-be/app/services/users"
 	"evote-be/routes"
 )
 
@@ -26,5 +33,65 @@ func (receiver *RouteServiceProvider) Boot(app foundation.Application) {
 }
 
 func (receiver *RouteServiceProvider) configureRateLimiting() {
+	// Authentication endpoints, per client
+	facades.RateLimiter().ForWithLimits("auth", func(ctx contractshttp.Context) []contractshttp.Limit {
+		return configuredLimits("auth", "ip:"+ctx.Request().Ip())
+	})
+
+	// Casting votes, per user. Runs after Auth.
+	facades.RateLimiter().ForWithLimits("votes", func(ctx contractshttp.Context) []contractshttp.Limit {
+		user, _ := users.Current(ctx)
+		return configuredLimits("votes", "user:"+strconv.FormatUint(uint64(user.ID), 10))
+	})
+
+	// Public poll lookups, per poll code
+	facades.RateLimiter().ForWithLimits("public_polls", func(ctx contractshttp.Context) []contractshttp.Limit {
+		return configuredLimits("public_polls", "code:"+ctx.Request().Query("code"))
+	})
+
+	// Proof-of-work challenges, per client
+	facades.RateLimiter().ForWithLimits("pow", func(ctx contractshttp.Context) []contractshttp.Limit {
+		return configuredLimits("pow", "ip:"+ctx.Request().Ip())
+	})
+
+	// Login emails, per client and per address
+	facades.RateLimiter().ForWithLimits("passwordless", func(ctx contractshttp.Context) []contractshttp.Limit {
+		if !facades.Config().GetBool("rate_limit.enabled", true) {
+			return nil
+		}
+
+		return []contractshttp.Limit{
+			limit.PerHour(facades.Config().GetInt("auth.passwordless.per_ip", 20)).
+				By("ip:" + ctx.Request().Ip()).
+				Response(tooManyRequests),
+			limit.PerHour(facades.Config().GetInt("auth.passwordless.per_email", 5)).
+				By("email:" + strings.ToLower(strings.TrimSpace(ctx.Request().Input("email")))).
+				Response(tooManyRequests),
+		}
+	})
+}
+
+// configuredLimits returns the limit of a named limiter of config/rate_limit.go
+// for the key, none when rate limiting or the limiter is off
+func configuredLimits(name, key string) []contractshttp.Limit {
+	if !facades.Config().GetBool("rate_limit.enabled", true) {
+		return nil
+	}
+
+	attempts := facades.Config().GetInt("rate_limit.limiters." + name + ".attempts")
+	if attempts <= 0 {
+		return nil
+	}
+	decay := max(facades.Config().GetInt("rate_limit.limiters."+name+".decay", 1), 1)
+
+	return []contractshttp.Limit{
+		limit.PerMinutes(decay, attempts).By(key).Response(tooManyRequests),
+	}
+}
 
+func tooManyRequests(ctx contractshttp.Context) {
+	_ = ctx.Response().Json(contractshttp.StatusTooManyRequests, models.ErrorResponse{
+		Message: "Too many requests",
+		Errors:  "Please try again later",
+	}).Abort()
 }
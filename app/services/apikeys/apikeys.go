@@ -0,0 +1,182 @@
+package apikeys
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+)
+
+// KeyPrefix starts every API key, the auth middleware tells keys from access
+// tokens by it
+const KeyPrefix = "evk_"
+
+// Scopes an API key can be granted
+const (
+	PollsRead  = "polls:read"
+	PollsWrite = "polls:write"
+	VotesRead  = "votes:read"
+	VotesWrite = "votes:write"
+)
+
+// Scopes All scopes an API key can be granted
+var Scopes = []string{PollsRead, PollsWrite, VotesRead, VotesWrite}
+
+const (
+	// keyCacheTTL bounds how long another instance may accept an API key after
+	// it was revoked
+	keyCacheTTL = time.Minute
+	// lastUsedInterval limits how often the last used time of a key is written
+	lastUsedInterval = time.Minute
+)
+
+var (
+	ErrInvalidKey   = errors.New("the API key is invalid, expired or revoked")
+	ErrInvalidScope = errors.New("invalid scope, use one of " + strings.Join(Scopes, ", "))
+	ErrExpiry       = errors.New("the expiry of the API key is too far away")
+	ErrTooManyKeys  = errors.New("too many active API keys, revoke one first")
+	ErrNotFound     = errors.New("API key not found or already revoked")
+)
+
+// IsKey reports whether a bearer token is an API key
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+// ExpiresAt returns when a key created at now expires after the days, the
+// configured default is used for 0
+func ExpiresAt(days int, now time.Time) (time.Time, error) {
+	if days <= 0 {
+		days = facades.Config().GetInt("auth.api_keys.expire", 90)
+	}
+	if days > facades.Config().GetInt("auth.api_keys.max_expire", 365) {
+		return time.Time{}, ErrExpiry
+	}
+
+	return now.AddDate(0, 0, days), nil
+}
+
+// Create creates an API key of the user and returns it together with the key,
+// which is only known at this point
+func Create(userID uint, name string, scopes []string, expiresAt time.Time) (models.ApiKeys, string, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(Scopes, scope) {
+			return models.ApiKeys{}, "", ErrInvalidScope
+		}
+	}
+
+	var active int64
+	if err := facades.Orm().Query().Model(&models.ApiKeys{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Count(&active); err != nil {
+		return models.ApiKeys{}, "", err
+	}
+	if active >= int64(facades.Config().GetInt("auth.api_keys.max_keys", 20)) {
+		return models.ApiKeys{}, "", ErrTooManyKeys
+	}
+
+	token, _, err := tokens.Generate()
+	if err != nil {
+		return models.ApiKeys{}, "", err
+	}
+	plain := KeyPrefix + token
+
+	slices.Sort(scopes)
+	key := models.ApiKeys{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(KeyPrefix)+8],
+		TokenHash: tokens.Hash(plain),
+		Scopes:    strings.Join(slices.Compact(scopes), ","),
+		ExpiresAt: expiresAt,
+	}
+	if err := facades.Orm().Query().Create(&key); err != nil {
+		return models.ApiKeys{}, "", err
+	}
+
+	return key, plain, nil
+}
+
+// Authenticate returns the API key of a request, ErrInvalidKey when it is
+// unknown, expired or revoked
+func Authenticate(plain string) (models.ApiKeys, error) {
+	hash := tokens.Hash(plain)
+	value, err := facades.Cache().Remember(keyCacheKey(hash), keyCacheTTL, func() (any, error) {
+		var key models.ApiKeys
+		if err := facades.Orm().Query().Where("token_hash = ?", hash).First(&key); err != nil {
+			return nil, err
+		}
+		// Unknown keys are not cached, or clients could fill the cache with them
+		if key.ID == 0 {
+			return nil, ErrInvalidKey
+		}
+		return key, nil
+	})
+	if err != nil {
+		return models.ApiKeys{}, err
+	}
+
+	key, _ := value.(models.ApiKeys)
+	if !Valid(key, time.Now()) {
+		return models.ApiKeys{}, ErrInvalidKey
+	}
+	return key, nil
+}
+
+// Valid reports whether the key may be used at now
+func Valid(key models.ApiKeys, now time.Time) bool {
+	return key.ID != 0 && key.RevokedAt == nil && now.Before(key.ExpiresAt)
+}
+
+// Allows reports whether the key was granted all of the scopes
+func Allows(key models.ApiKeys, scopes ...string) bool {
+	granted := key.ScopeList()
+	for _, scope := range scopes {
+		if !slices.Contains(granted, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// Touch records that a key is in use, at most once per interval
+func Touch(key models.ApiKeys) {
+	if !facades.Cache().Add("auth:api_key_used:"+key.TokenHash, true, lastUsedInterval) {
+		return
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.ApiKeys{}).Where("id = ?", key.ID).
+		Update("last_used_at", time.Now()); err != nil {
+		facades.Log().Errorf("Failed to update last used time of API key %d: %v", key.ID, err)
+	}
+}
+
+// Revoke revokes an active API key of the user
+func Revoke(userID uint, id string) error {
+	var key models.ApiKeys
+	if err := facades.Orm().Query().
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		First(&key); err != nil {
+		return err
+	}
+	if key.ID == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.ApiKeys{}).Where("id = ?", key.ID).
+		Update("revoked_at", time.Now()); err != nil {
+		return err
+	}
+	facades.Cache().Forget(keyCacheKey(key.TokenHash))
+
+	return nil
+}
+
+func keyCacheKey(tokenHash string) string {
+	return "auth:api_key:" + tokenHash
+}
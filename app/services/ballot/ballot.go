@@ -0,0 +1,206 @@
+package ballot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// Heuristics a vote is scored against
+const (
+	SignalIPBurst           = "ip_burst"
+	SignalSharedFingerprint = "shared_fingerprint"
+	SignalNewAccount        = "new_account"
+	SignalMissingUserAgent  = "missing_user_agent"
+)
+
+var (
+	ErrInvalidStatus  = errors.New("the review status must be approved or quarantined")
+	ErrReviewConflict = errors.New("the vote was reviewed by someone else in the meantime")
+	ErrPollClosed     = errors.New("votes can only be reviewed while the poll is active or paused")
+)
+
+// Metadata of the request a vote is cast with, the IP and fingerprint are
+// hashed before they are stored
+type Metadata struct {
+	IPHash      string
+	UserAgent   string
+	Fingerprint string
+}
+
+// NewMetadata hashes the client IP and device fingerprint of a request
+func NewMetadata(ip, userAgent, fingerprint string) Metadata {
+	return Metadata{
+		IPHash:      Hash(ip),
+		UserAgent:   strings.TrimSpace(userAgent),
+		Fingerprint: Hash(strings.TrimSpace(fingerprint)),
+	}
+}
+
+// Hash returns a hash keyed with the application key, so that the few
+// possible IPs can't be recovered by hashing them all. Empty stays empty.
+func Hash(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, []byte(facades.Config().GetString("app.key")))
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Rules are the configured heuristics with the weight each adds to the score
+type Rules struct {
+	Threshold              int
+	IPWindow               time.Duration
+	IPVotes                int64
+	IPWeight               int
+	FingerprintVotes       int64
+	FingerprintWeight      int
+	NewAccount             time.Duration
+	NewAccountWeight       int
+	MissingUserAgentWeight int
+}
+
+// ConfiguredRules returns the rules of the poll.ballot_stuffing config
+func ConfiguredRules() Rules {
+	config := facades.Config()
+	return Rules{
+		Threshold:              config.GetInt("poll.ballot_stuffing.threshold", 50),
+		IPWindow:               time.Duration(config.GetInt("poll.ballot_stuffing.ip_window", 10)) * time.Minute,
+		IPVotes:                int64(config.GetInt("poll.ballot_stuffing.ip_votes", 3)),
+		IPWeight:               config.GetInt("poll.ballot_stuffing.ip_weight", 40),
+		FingerprintVotes:       int64(config.GetInt("poll.ballot_stuffing.fingerprint_votes", 1)),
+		FingerprintWeight:      config.GetInt("poll.ballot_stuffing.fingerprint_weight", 50),
+		NewAccount:             time.Duration(config.GetInt("poll.ballot_stuffing.new_account", 24)) * time.Hour,
+		NewAccountWeight:       config.GetInt("poll.ballot_stuffing.new_account_weight", 30),
+		MissingUserAgentWeight: config.GetInt("poll.ballot_stuffing.missing_user_agent_weight", 20),
+	}
+}
+
+// Observation is what is known about a vote when it is cast
+type Observation struct {
+	// VotesFromIP counts earlier votes of the poll from the IP within the window
+	VotesFromIP int64
+	// VotesWithFingerprint counts earlier votes of the poll from the device
+	VotesWithFingerprint int64
+	AccountAge           time.Duration
+	UserAgent            string
+}
+
+// Assessment is the score of a vote with the heuristics that matched
+type Assessment struct {
+	Score   int
+	Signals []string
+	Flagged bool
+}
+
+// Score scores an observation against the rules
+func Score(observation Observation, rules Rules) Assessment {
+	assessment := Assessment{Signals: []string{}}
+	match := func(signal string, weight int) {
+		if weight > 0 {
+			assessment.Score += weight
+			assessment.Signals = append(assessment.Signals, signal)
+		}
+	}
+
+	if rules.IPVotes > 0 && observation.VotesFromIP >= rules.IPVotes {
+		match(SignalIPBurst, rules.IPWeight)
+	}
+	if rules.FingerprintVotes > 0 && observation.VotesWithFingerprint >= rules.FingerprintVotes {
+		match(SignalSharedFingerprint, rules.FingerprintWeight)
+	}
+	if observation.AccountAge < rules.NewAccount {
+		match(SignalNewAccount, rules.NewAccountWeight)
+	}
+	if observation.UserAgent == "" {
+		match(SignalMissingUserAgent, rules.MissingUserAgentWeight)
+	}
+	assessment.Flagged = assessment.Score > 0 && assessment.Score >= rules.Threshold
+
+	return assessment
+}
+
+// Assess observes a vote of the user in the poll and scores it, the query is
+// the transaction the vote is recorded in
+func Assess(query orm.Query, pollID uint, user models.User, metadata Metadata, now time.Time) (Assessment, error) {
+	rules := ConfiguredRules()
+	observation := Observation{
+		AccountAge: now.Sub(user.CreatedAt.StdTime()),
+		UserAgent:  metadata.UserAgent,
+	}
+
+	if metadata.IPHash != "" {
+		if err := query.Model(&models.Votes{}).
+			Where("poll_id = ? AND ip_hash = ? AND created_at > ?", pollID, metadata.IPHash, now.Add(-rules.IPWindow)).
+			Count(&observation.VotesFromIP); err != nil {
+			return Assessment{}, err
+		}
+	}
+	if metadata.Fingerprint != "" {
+		if err := query.Model(&models.Votes{}).
+			Where("poll_id = ? AND fingerprint = ?", pollID, metadata.Fingerprint).
+			Count(&observation.VotesWithFingerprint); err != nil {
+			return Assessment{}, err
+		}
+	}
+
+	return Score(observation, rules), nil
+}
+
+// Review approves or quarantines a vote of a poll. Quarantined votes don't
+// count towards the results of their option until they are approved, so the
+// results of a finished poll stay as they were published.
+func Review(poll models.Polls, vote models.Votes, status string) (models.Votes, error) {
+	if status != models.VoteApproved && status != models.VoteQuarantined {
+		return vote, ErrInvalidStatus
+	}
+	if poll.Status != models.Active && poll.Status != models.Paused {
+		return vote, ErrPollClosed
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return vote, err
+	}
+
+	// The previous status in the condition keeps concurrent reviews from
+	// counting a vote twice
+	now := time.Now()
+	result, err := tx.Exec("UPDATE votes SET review_status = ?, reviewed_at = ? WHERE id = ? AND review_status = ?", status, now, vote.ID, vote.ReviewStatus)
+	if err != nil {
+		tx.Rollback()
+		return vote, err
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return vote, ErrReviewConflict
+	}
+
+	switch {
+	case status == models.VoteQuarantined && vote.ReviewStatus != models.VoteQuarantined:
+		_, err = tx.Exec("UPDATE options SET votes_count = votes_count - 1 WHERE id = ? AND votes_count > 0", vote.OptionID)
+	case status != models.VoteQuarantined && vote.ReviewStatus == models.VoteQuarantined:
+		_, err = tx.Exec("UPDATE options SET votes_count = votes_count + 1 WHERE id = ?", vote.OptionID)
+	}
+	if err != nil {
+		tx.Rollback()
+		return vote, err
+	}
+	if err := tx.Commit(); err != nil {
+		return vote, err
+	}
+
+	vote.ReviewStatus = status
+	vote.ReviewedAt = &now
+	return vote, nil
+}
@@ -0,0 +1,241 @@
+package collaborators
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+	"evote-be/app/services/organizations"
+	"evote-be/app/services/tokens"
+)
+
+var (
+	ErrInvalidInvitation = errors.New("the invitation is invalid or expired")
+	ErrInvitationEmail   = errors.New("the invitation was sent to another email")
+	ErrAlreadyOwner      = errors.New("the user already owns the poll")
+	ErrNotCollaborator   = errors.New("the user is not a collaborator of the poll")
+)
+
+// Roles are the grants that can be given to collaborators
+var Roles = []models.CollaboratorRole{models.CollaboratorOwner, models.CollaboratorEditor, models.CollaboratorViewer}
+
+// RoleOf returns the grant of the user on the poll, empty when the user has
+// none. Managers of the organization of the poll own it, other members view it.
+func RoleOf(poll models.Polls, userID uint) (models.CollaboratorRole, error) {
+	if poll.UserID == userID {
+		return models.CollaboratorOwner, nil
+	}
+
+	var collaborator models.PollCollaborators
+	if err := facades.Orm().Query().Where("poll_id = ? AND user_id = ?", poll.ID, userID).First(&collaborator); err != nil {
+		return "", err
+	}
+	if poll.OrganizationID == nil || collaborator.Role == models.CollaboratorOwner {
+		return collaborator.Role, nil
+	}
+
+	member, err := organizations.RoleOf(*poll.OrganizationID, userID)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case member.CanManage():
+		return models.CollaboratorOwner, nil
+	case member != "" && collaborator.Role == "":
+		return models.CollaboratorViewer, nil
+	}
+
+	return collaborator.Role, nil
+}
+
+// List returns the primary owner followed by the collaborators of the poll
+func List(poll models.Polls) ([]models.PollCollaboratorResponse, error) {
+	var owner models.User
+	if err := facades.Orm().Query().Where("id = ?", poll.UserID).First(&owner); err != nil {
+		return nil, err
+	}
+
+	var collaborators []models.PollCollaborators
+	if err := facades.Orm().Query().Where("poll_id = ?", poll.ID).OrderBy("id").Find(&collaborators); err != nil {
+		return nil, err
+	}
+
+	resp := []models.PollCollaboratorResponse{{
+		UserID: int(owner.ID),
+		Name:   owner.Name,
+		Email:  owner.Email,
+		Role:   models.CollaboratorOwner,
+	}}
+	for _, collaborator := range collaborators {
+		var user models.User
+		if err := facades.Orm().Query().Where("id = ?", collaborator.UserID).First(&user); err != nil {
+			return nil, err
+		}
+		resp = append(resp, models.PollCollaboratorResponse{
+			UserID: int(user.ID),
+			Name:   user.Name,
+			Email:  user.Email,
+			Role:   collaborator.Role,
+		})
+	}
+
+	return resp, nil
+}
+
+// Invite emails an invitation to collaborate on the poll, replacing a pending
+// invitation sent to the same email
+func Invite(poll models.Polls, inviter models.User, email string, role models.CollaboratorRole) (models.PollInvitations, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return models.PollInvitations{}, err
+	}
+
+	if _, err := facades.Orm().Query().
+		Where("poll_id = ? AND email = ? AND accepted_at IS NULL", poll.ID, email).
+		Delete(&models.PollInvitations{}); err != nil {
+		return models.PollInvitations{}, err
+	}
+	invitation := models.PollInvitations{
+		PollID:    poll.ID,
+		Email:     email,
+		Role:      role,
+		Token:     tokenHash,
+		InvitedBy: inviter.ID,
+		ExpiresAt: time.Now().Add(time.Duration(facades.Config().GetInt("poll.invitations.expire", 72)) * time.Hour),
+	}
+	if err := facades.Orm().Query().Create(&invitation); err != nil {
+		return models.PollInvitations{}, err
+	}
+
+	// The email is queued by the listener of the event
+	if err := facades.Event().Job(&events.CollaboratorInvited{}, []event.Arg{
+		{Type: "uint", Value: poll.ID},
+		{Type: "string", Value: email},
+		{Type: "string", Value: token},
+		{Type: "string", Value: string(role)},
+		{Type: "string", Value: inviter.Name},
+	}).Dispatch(); err != nil {
+		return models.PollInvitations{}, err
+	}
+
+	return invitation, nil
+}
+
+// Accept uses an invitation for the user it was sent to and grants its role,
+// a grant the user already had is replaced
+func Accept(token string, user models.User) (models.PollCollaborators, error) {
+	var invitation models.PollInvitations
+	if err := facades.Orm().Query().
+		Where("token = ? AND accepted_at IS NULL AND expires_at > ?", tokens.Hash(token), time.Now()).
+		First(&invitation); err != nil {
+		return models.PollCollaborators{}, err
+	}
+	if invitation.ID == 0 {
+		return models.PollCollaborators{}, ErrInvalidInvitation
+	}
+	if !strings.EqualFold(invitation.Email, user.Email) {
+		return models.PollCollaborators{}, ErrInvitationEmail
+	}
+
+	var poll models.Polls
+	if err := facades.Orm().Query().Where("id = ?", invitation.PollID).First(&poll); err != nil {
+		return models.PollCollaborators{}, err
+	}
+	if poll.ID == 0 {
+		return models.PollCollaborators{}, ErrInvalidInvitation
+	}
+	if poll.UserID == user.ID {
+		return models.PollCollaborators{}, ErrAlreadyOwner
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return models.PollCollaborators{}, err
+	}
+
+	// The condition keeps an invitation single-use when requests race
+	result, err := tx.Model(&models.PollInvitations{}).
+		Where("id = ? AND accepted_at IS NULL", invitation.ID).
+		Update("accepted_at", time.Now())
+	if err != nil {
+		tx.Rollback()
+		return models.PollCollaborators{}, err
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return models.PollCollaborators{}, ErrInvalidInvitation
+	}
+
+	if _, err := tx.Where("poll_id = ? AND user_id = ?", poll.ID, user.ID).Delete(&models.PollCollaborators{}); err != nil {
+		tx.Rollback()
+		return models.PollCollaborators{}, err
+	}
+	collaborator := models.PollCollaborators{PollID: poll.ID, UserID: user.ID, Role: invitation.Role}
+	if err := tx.Create(&collaborator); err != nil {
+		tx.Rollback()
+		return models.PollCollaborators{}, err
+	}
+
+	return collaborator, tx.Commit()
+}
+
+// Remove revokes the grant of a collaborator on the poll
+func Remove(poll models.Polls, userID uint) error {
+	result, err := facades.Orm().Query().Where("poll_id = ? AND user_id = ?", poll.ID, userID).Delete(&models.PollCollaborators{})
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotCollaborator
+	}
+
+	return nil
+}
+
+// Transfer makes a collaborator the primary owner of the poll, the previous
+// owner stays on as an editor
+func Transfer(poll models.Polls, userID uint) error {
+	if poll.UserID == userID {
+		return ErrAlreadyOwner
+	}
+
+	var collaborator models.PollCollaborators
+	if err := facades.Orm().Query().Where("poll_id = ? AND user_id = ?", poll.ID, userID).First(&collaborator); err != nil {
+		return err
+	}
+	if collaborator.ID == 0 {
+		return ErrNotCollaborator
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return err
+	}
+
+	// The condition keeps concurrent transfers from both succeeding
+	result, err := tx.Model(&models.Polls{}).Where("id = ? AND user_id = ?", poll.ID, poll.UserID).Update("user_id", userID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return ErrNotCollaborator
+	}
+	if _, err := tx.Delete(&collaborator); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Create(&models.PollCollaborators{PollID: poll.ID, UserID: poll.UserID, Role: models.CollaboratorEditor}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,105 @@
+package eligibility
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/organizations"
+)
+
+// Error tells a voter which rule of the poll they do not meet, Code is stable
+// for clients to branch on
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var (
+	ErrDomainNotAllowed = &Error{Code: "EMAIL_DOMAIN_NOT_ALLOWED", Message: "Your email domain is not allowed to vote in this poll"}
+	ErrNotMember        = &Error{Code: "ORGANIZATION_MEMBERSHIP_REQUIRED", Message: "Only members of the organization can vote in this poll"}
+	ErrAccountTooNew    = &Error{Code: "ACCOUNT_TOO_NEW", Message: "Your account is too new to vote in this poll"}
+)
+
+// Voter is what the rules are checked against. Only users with a verified
+// email get past the auth middleware, so there is no rule for it.
+type Voter struct {
+	Email     string
+	CreatedAt time.Time
+	// Member reports whether the voter is a member of the organization
+	Member func(organizationID uint) (bool, error)
+}
+
+// Check returns the first rule the voter does not meet as an *Error, nil when
+// the voter is eligible
+func Check(rules models.PollEligibilityRules, voter Voter, now time.Time) error {
+	if domains := rules.Domains(); len(domains) > 0 {
+		_, domain, _ := strings.Cut(strings.ToLower(voter.Email), "@")
+		if !slices.Contains(domains, domain) {
+			return ErrDomainNotAllowed
+		}
+	}
+
+	if rules.MinAccountAgeDays > 0 && voter.CreatedAt.After(now.AddDate(0, 0, -rules.MinAccountAgeDays)) {
+		return ErrAccountTooNew
+	}
+
+	if rules.OrganizationID != nil {
+		member, err := voter.Member(*rules.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if !member {
+			return ErrNotMember
+		}
+	}
+
+	return nil
+}
+
+// Rules returns the eligibility rules of the poll, empty rules when it has none
+func Rules(pollID uint) (models.PollEligibilityRules, error) {
+	var rules models.PollEligibilityRules
+	if err := facades.Orm().Query().Where("poll_id = ?", pollID).First(&rules); err != nil {
+		return models.PollEligibilityRules{}, err
+	}
+	rules.PollID = pollID
+
+	return rules, nil
+}
+
+// Save replaces the eligibility rules of the poll
+func Save(rules models.PollEligibilityRules) (models.PollEligibilityRules, error) {
+	var existing models.PollEligibilityRules
+	if err := facades.Orm().Query().Where("poll_id = ?", rules.PollID).First(&existing); err != nil {
+		return models.PollEligibilityRules{}, err
+	}
+	rules.ID = existing.ID
+	rules.CreatedAt = existing.CreatedAt
+
+	return rules, facades.Orm().Query().Save(&rules)
+}
+
+// Evaluate checks the user against the eligibility rules of the poll
+func Evaluate(pollID uint, user models.User) error {
+	rules, err := Rules(pollID)
+	if err != nil {
+		return err
+	}
+
+	return Check(rules, Voter{
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt.StdTime(),
+		Member: func(organizationID uint) (bool, error) {
+			role, err := organizations.RoleOf(organizationID, user.ID)
+			return role != "", err
+		},
+	}, time.Now())
+}
@@ -0,0 +1,338 @@
+package lifecycle
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+)
+
+var (
+	ErrInvalidTransition = errors.New("the poll cannot change to this status")
+	ErrStatusChanged     = errors.New("the poll status was changed in the meantime")
+)
+
+// scheduler keeps one timer per pending transition of a poll
+type scheduler struct {
+	mu      sync.Mutex
+	running bool
+	timers  map[uint][]*time.Timer
+	stop    chan struct{}
+}
+
+var instance = &scheduler{timers: map[uint][]*time.Timer{}}
+
+// Start rebuilds the timers from the database and keeps them in sync until
+// Stop is called. When the first rebuild fails, e.g. because the database is
+// not ready yet, it is retried with a backoff and the error is returned.
+func Start() error {
+	instance.mu.Lock()
+	if instance.running {
+		instance.mu.Unlock()
+		return nil
+	}
+	instance.running = true
+	instance.stop = make(chan struct{})
+	stop := instance.stop
+	instance.mu.Unlock()
+
+	err := Rebuild()
+
+	resync := time.Duration(facades.Config().GetInt("poll.scheduler.resync", 5)) * time.Minute
+	wait := resync
+	if err != nil {
+		wait = time.Second
+	}
+	go func() {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C:
+				if err := Rebuild(); err != nil {
+					facades.Log().Errorf("Failed to rebuild poll timers: %v", err)
+					wait = min(wait*2, resync)
+				} else {
+					wait = resync
+				}
+				timer.Reset(wait)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return err
+}
+
+// Stop cancels all timers
+func Stop() {
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	if !instance.running {
+		return
+	}
+	instance.running = false
+	close(instance.stop)
+
+	for id := range instance.timers {
+		instance.cancel(id)
+	}
+}
+
+// Rebuild schedules every transition due within the horizon. Overdue
+// transitions, e.g. missed while the server was down, run immediately.
+func Rebuild() error {
+	horizon := time.Now().Add(time.Duration(facades.Config().GetInt("poll.scheduler.horizon", 60)) * time.Minute)
+
+	var polls []models.Polls
+	if err := facades.Orm().Query().
+		Where("(status = ? AND start_date <= ?) OR (status IN ? AND end_date <= ?)",
+			models.Scheduled, horizon, []models.Status{models.Active, models.Paused}, horizon).
+		Find(&polls); err != nil {
+		return err
+	}
+
+	for _, poll := range polls {
+		Schedule(poll)
+	}
+
+	return nil
+}
+
+// Schedule replaces the timers of a poll with ones matching its current
+// status and dates. It does nothing when the scheduler is not running.
+func Schedule(poll models.Polls) {
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	if !instance.running {
+		return
+	}
+	instance.cancel(poll.ID)
+
+	horizon := time.Now().Add(time.Duration(facades.Config().GetInt("poll.scheduler.horizon", 60)) * time.Minute)
+	pollID := poll.ID
+
+	var timers []*time.Timer
+	if poll.Status == models.Scheduled && !poll.StartDate.After(horizon) {
+		timers = append(timers, time.AfterFunc(time.Until(poll.StartDate), func() {
+			if _, err := StartPoll(pollID); err != nil {
+				facades.Log().Errorf("Failed to start poll %d: %v", pollID, err)
+			}
+		}))
+	}
+	if (poll.Status == models.Scheduled || poll.Status == models.Active || poll.Status == models.Paused) && !poll.EndDate.After(horizon) {
+		timers = append(timers, time.AfterFunc(time.Until(poll.EndDate), func() {
+			// A poll whose start was missed is started first
+			if _, err := StartPoll(pollID); err != nil {
+				facades.Log().Errorf("Failed to start poll %d: %v", pollID, err)
+			}
+			if _, err := EndPoll(pollID); err != nil {
+				facades.Log().Errorf("Failed to end poll %d: %v", pollID, err)
+			}
+		}))
+	}
+
+	if len(timers) > 0 {
+		instance.timers[pollID] = timers
+	}
+}
+
+// Cancel removes the timers of a poll
+func Cancel(pollID uint) {
+	instance.mu.Lock()
+	defer instance.mu.Unlock()
+
+	instance.cancel(pollID)
+}
+
+func (s *scheduler) cancel(pollID uint) {
+	for _, timer := range s.timers[pollID] {
+		timer.Stop()
+	}
+	delete(s.timers, pollID)
+}
+
+// Transition moves a poll from its current status to the given one on behalf
+// of a user and records the reason.
+func Transition(poll models.Polls, to models.Status, reason string, userID *uint) error {
+	if !poll.Status.CanTransitionTo(to) {
+		return ErrInvalidTransition
+	}
+
+	ok, err := apply(poll.ID, poll.Status, to, reason, userID, nil, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
+// Publish moves a draft to the given status and sets its public code in the
+// same update, so a draft that failed to publish keeps having no code
+func Publish(poll models.Polls, to models.Status, code string, reason string, userID *uint) error {
+	if poll.Status != models.Draft || !poll.Status.CanTransitionTo(to) {
+		return ErrInvalidTransition
+	}
+
+	ok, err := apply(poll.ID, poll.Status, to, reason, userID, map[string]any{"code": code}, "")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
+// IsLocked reports whether the content of a poll is frozen, i.e. voting has
+// begun or votes were cast
+func IsLocked(poll models.Polls) (bool, error) {
+	if poll.Status.LocksContent() {
+		return true, nil
+	}
+
+	var hasVotes bool
+	if err := facades.Orm().Query().Model(&models.Votes{}).Where("poll_id = ?", poll.ID).Exists(&hasVotes); err != nil {
+		return false, err
+	}
+
+	return hasVotes, nil
+}
+
+// StartPoll moves a due scheduled poll to active. The status is changed with a
+// conditional update, so when several instances race only one of them reports
+// the transition and fires the event.
+func StartPoll(pollID uint) (bool, error) {
+	return apply(pollID, models.Scheduled, models.Active, "start date reached", nil, nil, "start_date <= ?", time.Now())
+}
+
+// EndPoll moves an active or paused poll past its end date to done, see StartPoll
+func EndPoll(pollID uint) (bool, error) {
+	for _, from := range []models.Status{models.Active, models.Paused} {
+		ok, err := apply(pollID, from, models.Done, "end date reached", nil, nil, "end_date <= ?", time.Now())
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// StartDue starts every scheduled poll whose start date has passed
+func StartDue() error {
+	var ids []uint
+	if err := facades.Orm().Query().Model(&models.Polls{}).
+		Where("status = ? AND start_date <= ?", models.Scheduled, time.Now()).
+		Pluck("id", &ids); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := StartPoll(id); err != nil {
+			facades.Log().Errorf("Failed to start poll %d: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// EndDue ends every active or paused poll whose end date has passed
+func EndDue() error {
+	var ids []uint
+	if err := facades.Orm().Query().Model(&models.Polls{}).
+		Where("status IN ? AND end_date <= ?", []models.Status{models.Active, models.Paused}, time.Now()).
+		Pluck("id", &ids); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := EndPoll(id); err != nil {
+			facades.Log().Errorf("Failed to end poll %d: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+// apply changes the status, and the optional other columns, only if the poll
+// is still in the expected one and the optional condition holds, then records
+// the transition and fires events.
+func apply(pollID uint, from, to models.Status, reason string, userID *uint, values map[string]any, condition string, args ...any) (bool, error) {
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return false, err
+	}
+
+	query := tx.Model(&models.Polls{}).Where("id = ? AND status = ?", pollID, from)
+	if condition != "" {
+		query = query.Where(condition, args...)
+	}
+	columns := map[string]any{"status": to}
+	for column, value := range values {
+		columns[column] = value
+	}
+	result, err := query.Update(columns)
+	if err != nil {
+		tx.Rollback()
+		return false, err
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return false, nil
+	}
+
+	if err := tx.Create(&models.PollTransitions{
+		PollID:     pollID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		UserID:     userID,
+	}); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+
+	if err := facades.Event().Job(&events.PollStatusChanged{}, []event.Arg{
+		{Type: "uint", Value: pollID},
+		{Type: "string", Value: string(from)},
+		{Type: "string", Value: string(to)},
+		{Type: "string", Value: reason},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch status changed event for poll %d: %v", pollID, err)
+	}
+
+	// Voting opened for the first time or closed for good
+	switch {
+	case to == models.Active && (from == models.Scheduled || from == models.Draft):
+		dispatch(&events.PollStarted{}, pollID)
+	case to == models.Done:
+		dispatch(&events.PollEnded{}, pollID)
+	}
+
+	return true, nil
+}
+
+func dispatch(e event.Event, pollID uint) {
+	if err := facades.Event().Job(e, []event.Arg{
+		{Type: "uint", Value: pollID},
+	}).Dispatch(); err != nil {
+		facades.Log().Errorf("Failed to dispatch poll event for poll %d: %v", pollID, err)
+	}
+}
@@ -0,0 +1,154 @@
+package lockout
+
+import (
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+)
+
+// Attempt is a password login as logged
+type Attempt struct {
+	Email     string
+	UserID    *uint
+	IPAddress string
+	UserAgent string
+}
+
+// Normalize returns the email as logins are counted by
+func Normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// Backoff returns the delay after the given number of failures, doubling from
+// base with every failure and capped at ceiling
+func Backoff(failures int, base, ceiling time.Duration) time.Duration {
+	if failures <= 0 || base <= 0 {
+		return 0
+	}
+
+	delay := base
+	for i := 1; i < failures; i++ {
+		delay *= 2
+		if delay >= ceiling {
+			return ceiling
+		}
+	}
+	if delay > ceiling {
+		return ceiling
+	}
+
+	return delay
+}
+
+// LockedFor returns how long logins of the attempt are refused, 0 when the
+// email and client are neither locked nor waiting for the delay of a failure
+func LockedFor(attempt Attempt) time.Duration {
+	until := max(
+		facades.Cache().GetInt64(emailKey(attempt.Email)),
+		facades.Cache().GetInt64(ipKey(attempt.IPAddress)),
+		facades.Cache().GetInt64(delayKey(attempt.Email)),
+	)
+	if until == 0 {
+		return 0
+	}
+
+	return max(time.Until(time.UnixMilli(until)), 0)
+}
+
+// Record logs an attempt that does not count towards a lockout
+func Record(attempt Attempt, successful bool, reason string) error {
+	return facades.Orm().Query().Create(&models.LoginAttempts{
+		Email:      Normalize(attempt.Email),
+		UserID:     attempt.UserID,
+		IPAddress:  attempt.IPAddress,
+		UserAgent:  attempt.UserAgent,
+		Successful: successful,
+		Reason:     reason,
+	})
+}
+
+// Succeed logs a login with the right password, which clears the failures of
+// the email
+func Succeed(attempt Attempt) error {
+	return Record(attempt, true, models.LoginSucceeded)
+}
+
+// Fail logs a login with wrong credentials and locks the email or the client
+// after too many failures within the window. It returns the delay the email
+// is refused for, growing with every failure. Further failures after a lock
+// expired lock again until the window passed.
+func Fail(attempt Attempt) (time.Duration, error) {
+	if err := Record(attempt, false, models.LoginInvalidCredentials); err != nil {
+		return 0, err
+	}
+
+	since := time.Now().Add(-time.Duration(facades.Config().GetInt("auth.lockout.window", 15)) * time.Minute)
+	email := Normalize(attempt.Email)
+
+	// Failures of the email since its last successful login
+	var failures int64
+	if err := facades.Orm().Query().Model(&models.LoginAttempts{}).
+		Where("email = ? AND reason = ? AND created_at > ?", email, models.LoginInvalidCredentials, since).
+		Where("id > COALESCE((SELECT MAX(id) FROM login_attempts WHERE email = ? AND successful = ?), 0)", email, true).
+		Count(&failures); err != nil {
+		return 0, err
+	}
+
+	var ipFailures int64
+	if err := facades.Orm().Query().Model(&models.LoginAttempts{}).
+		Where("ip_address = ? AND reason = ? AND created_at > ?", attempt.IPAddress, models.LoginInvalidCredentials, since).
+		Count(&ipFailures); err != nil {
+		return 0, err
+	}
+
+	duration := time.Duration(facades.Config().GetInt("auth.lockout.duration", 15)) * time.Minute
+	until := time.Now().Add(duration).UnixMilli()
+	if failures >= int64(facades.Config().GetInt("auth.lockout.max_attempts", 5)) {
+		// The owner of the account learns about the lock once, failures racing
+		// to lock it find it locked already. Unknown emails stay unknown.
+		if locked := facades.Cache().Add(emailKey(email), until, duration); locked && attempt.UserID != nil {
+			if err := facades.Event().Job(&events.AccountLocked{}, []event.Arg{
+				{Type: "uint", Value: *attempt.UserID},
+				{Type: "string", Value: attempt.IPAddress},
+				{Type: "int", Value: int(duration.Minutes())},
+			}).Dispatch(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if ipFailures >= int64(facades.Config().GetInt("auth.lockout.max_attempts_per_ip", 20)) {
+		if err := facades.Cache().Put(ipKey(attempt.IPAddress), until, duration); err != nil {
+			return 0, err
+		}
+	}
+
+	delay := Backoff(
+		int(failures),
+		time.Duration(facades.Config().GetInt("auth.lockout.delay", 250))*time.Millisecond,
+		time.Duration(facades.Config().GetInt("auth.lockout.max_delay", 4000))*time.Millisecond,
+	)
+	if delay > 0 {
+		if err := facades.Cache().Put(delayKey(email), time.Now().Add(delay).UnixMilli(), delay); err != nil {
+			return 0, err
+		}
+	}
+
+	return delay, nil
+}
+
+func emailKey(email string) string {
+	return "auth:lockout:email:" + Normalize(email)
+}
+
+func delayKey(email string) string {
+	return "auth:lockout:delay:" + Normalize(email)
+}
+
+func ipKey(ip string) string {
+	return "auth:lockout:ip:" + ip
+}
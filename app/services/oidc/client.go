@@ -0,0 +1,363 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrInvalidIDToken = errors.New("the id token of the provider is invalid")
+	ErrNoEmail        = errors.New("the provider did not share an email address")
+)
+
+// Config configures a provider. Providers that support OpenID Connect only
+// need the issuer, the endpoints are discovered. Plain OAuth 2 providers like
+// GitHub set the endpoints instead, the identity is read from the userinfo
+// endpoint and the emails endpoint tells which addresses are verified.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	EmailsURL    string
+	JWKSURL      string
+	// HTTPClient defaults to a client with a 10 second timeout
+	HTTPClient *http.Client
+}
+
+// Identity is the account of a user at a provider
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Picture       string
+}
+
+// Provider signs in users with the authorization code flow and PKCE
+type Provider struct {
+	config Config
+
+	mu         sync.Mutex
+	discovered bool
+	keys       map[string]any
+}
+
+func NewProvider(config Config) *Provider {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Provider{config: config}
+}
+
+// AuthCodeURL returns the URL of the provider the user is sent to. The
+// verifier and nonce have to be presented again to Exchange.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, verifier, nonce string) (string, error) {
+	if err := p.discover(ctx); err != nil {
+		return "", err
+	}
+
+	opts := []oauth2.AuthCodeOption{oauth2.S256ChallengeOption(verifier)}
+	if p.config.Issuer != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+
+	return p.oauth2().AuthCodeURL(state, opts...), nil
+}
+
+// Exchange redeems the code the provider redirected the user back with and
+// returns the identity of the user
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (Identity, error) {
+	if err := p.discover(ctx); err != nil {
+		return Identity{}, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.config.HTTPClient)
+	token, err := p.oauth2().Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return Identity{}, err
+	}
+
+	// OpenID Connect providers return a signed id token
+	if p.config.Issuer != "" {
+		rawIDToken, _ := token.Extra("id_token").(string)
+		if rawIDToken == "" {
+			return Identity{}, ErrInvalidIDToken
+		}
+		return p.verifyIDToken(ctx, rawIDToken, nonce)
+	}
+
+	return p.userInfo(ctx, token)
+}
+
+func (p *Provider) oauth2() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       p.config.Scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.config.AuthURL,
+			TokenURL: p.config.TokenURL,
+		},
+	}
+}
+
+// discover fills in the endpoints of an OpenID Connect provider from its
+// discovery document, once
+func (p *Provider) discover(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovered || p.config.Issuer == "" {
+		return nil
+	}
+
+	var document struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	url := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, url, "", &document); err != nil {
+		return err
+	}
+	if document.Issuer != p.config.Issuer {
+		return fmt.Errorf("the discovery document is for issuer %q instead of %q", document.Issuer, p.config.Issuer)
+	}
+
+	if p.config.AuthURL == "" {
+		p.config.AuthURL = document.AuthorizationEndpoint
+	}
+	if p.config.TokenURL == "" {
+		p.config.TokenURL = document.TokenEndpoint
+	}
+	if p.config.UserInfoURL == "" {
+		p.config.UserInfoURL = document.UserInfoEndpoint
+	}
+	if p.config.JWKSURL == "" {
+		p.config.JWKSURL = document.JWKSURI
+	}
+	p.discovered = true
+
+	return nil
+}
+
+func (p *Provider) verifyIDToken(ctx context.Context, rawIDToken, nonce string) (Identity, error) {
+	var claims struct {
+		jwt.RegisteredClaims
+		Nonce         string `json:"nonce"`
+		Email         string `json:"email"`
+		EmailVerified any    `json:"email_verified"`
+		Name          string `json:"name"`
+		Picture       string `json:"picture"`
+	}
+
+	_, err := jwt.ParseWithClaims(rawIDToken, &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(p.config.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return Identity{}, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return Identity{}, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	if claims.Email == "" {
+		return Identity{}, ErrNoEmail
+	}
+
+	return Identity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: isTrue(claims.EmailVerified),
+		Name:          claims.Name,
+		Picture:       claims.Picture,
+	}, nil
+}
+
+// key returns the signing key of the provider with the id. The key set is
+// fetched again for unknown ids, as providers rotate their keys.
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	var set struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := p.getJSON(ctx, p.config.JWKSURL, "", &set); err != nil {
+		return nil, err
+	}
+
+	p.keys = map[string]any{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		switch jwk.Kty {
+		case "RSA":
+			n, nerr := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, eerr := base64.RawURLEncoding.DecodeString(jwk.E)
+			if nerr != nil || eerr != nil || len(e) > 4 {
+				continue
+			}
+			p.keys[jwk.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			x, xerr := base64.RawURLEncoding.DecodeString(jwk.X)
+			y, yerr := base64.RawURLEncoding.DecodeString(jwk.Y)
+			if jwk.Crv != "P-256" || xerr != nil || yerr != nil {
+				continue
+			}
+			p.keys[jwk.Kid] = &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		}
+	}
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+// userInfo reads the identity of a plain OAuth 2 provider
+func (p *Provider) userInfo(ctx context.Context, token *oauth2.Token) (Identity, error) {
+	var info map[string]any
+	if err := p.getJSON(ctx, p.config.UserInfoURL, token.AccessToken, &info); err != nil {
+		return Identity{}, err
+	}
+
+	identity := Identity{
+		Subject:       stringClaim(info, "sub", "id"),
+		Email:         stringClaim(info, "email"),
+		EmailVerified: isTrue(info["email_verified"]),
+		Name:          stringClaim(info, "name", "login"),
+		Picture:       stringClaim(info, "picture", "avatar_url"),
+	}
+	if identity.Subject == "" {
+		return Identity{}, errors.New("the provider did not share the id of the user")
+	}
+
+	// The profile email is not necessarily verified, the primary verified
+	// address of the emails endpoint is used instead
+	if p.config.EmailsURL != "" {
+		var emails []struct {
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
+		}
+		if err := p.getJSON(ctx, p.config.EmailsURL, token.AccessToken, &emails); err != nil {
+			return Identity{}, err
+		}
+
+		identity.EmailVerified = false
+		for _, email := range emails {
+			if email.Primary {
+				identity.Email = email.Email
+				identity.EmailVerified = email.Verified
+			}
+		}
+	}
+	if identity.Email == "" {
+		return Identity{}, ErrNoEmail
+	}
+
+	return identity, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, url, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s responded with status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// stringClaim returns the first of the claims that is set, numeric ids are
+// formatted as strings
+func stringClaim(claims map[string]any, names ...string) string {
+	for _, name := range names {
+		switch v := claims[name].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+// isTrue reads a boolean claim, some providers send it as a string
+func isTrue(v any) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+
+	return false
+}
@@ -0,0 +1,266 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+	"golang.org/x/oauth2"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+)
+
+var (
+	ErrUnknownProvider = errors.New("the login provider is not configured")
+	ErrInvalidState    = errors.New("the login state is invalid or expired, please try again")
+	ErrEmailTaken      = errors.New("an account with the email already exists, login with your password instead")
+)
+
+var (
+	mu        sync.Mutex
+	providers = map[string]*Provider{}
+)
+
+type state struct {
+	Provider string
+	Verifier string
+	Nonce    string
+}
+
+// Get returns the configured provider with the name. Providers are kept for
+// the lifetime of the process so discovery and keys are fetched once.
+func Get(name string) (*Provider, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if provider, ok := providers[name]; ok {
+		return provider, nil
+	}
+
+	if _, ok := facades.Config().Get("oidc.providers").(map[string]any)[name]; !ok {
+		return nil, ErrUnknownProvider
+	}
+	key := "oidc.providers." + name + "."
+	config := Config{
+		Issuer:       facades.Config().GetString(key + "issuer"),
+		ClientID:     facades.Config().GetString(key + "client_id"),
+		ClientSecret: facades.Config().GetString(key + "client_secret"),
+		RedirectURL:  strings.ReplaceAll(facades.Config().GetString("oidc.redirect_url"), "{provider}", name),
+		Scopes:       strings.Fields(facades.Config().GetString(key + "scopes")),
+		AuthURL:      facades.Config().GetString(key + "auth_url"),
+		TokenURL:     facades.Config().GetString(key + "token_url"),
+		UserInfoURL:  facades.Config().GetString(key + "userinfo_url"),
+		EmailsURL:    facades.Config().GetString(key + "emails_url"),
+	}
+	if config.ClientID == "" || (config.Issuer == "" && config.AuthURL == "") {
+		return nil, ErrUnknownProvider
+	}
+
+	providers[name] = NewProvider(config)
+	return providers[name], nil
+}
+
+// Start begins a login with the provider and returns the URL the user is sent
+// to together with the state the provider sends back
+func Start(ctx context.Context, name string) (string, string, error) {
+	provider, err := Get(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	value := hex.EncodeToString(b)
+	s := state{
+		Provider: name,
+		Verifier: oauth2.GenerateVerifier(),
+		Nonce:    oauth2.GenerateVerifier(),
+	}
+
+	url, err := provider.AuthCodeURL(ctx, value, s.Verifier, s.Nonce)
+	if err != nil {
+		return "", "", err
+	}
+	if err := facades.Cache().Put(stateKey(value), s, stateTTL()); err != nil {
+		return "", "", err
+	}
+
+	return url, value, nil
+}
+
+// Complete redeems the code the provider sent the user back with. A state is
+// accepted once and only for the provider it was created for.
+func Complete(ctx context.Context, name, value, code string) (Identity, error) {
+	provider, err := Get(name)
+	if err != nil {
+		return Identity{}, err
+	}
+
+	if !facades.Cache().Add(stateKey(value)+":used", true, stateTTL()) {
+		return Identity{}, ErrInvalidState
+	}
+	s, ok := facades.Cache().Pull(stateKey(value)).(state)
+	if !ok || s.Provider != name {
+		return Identity{}, ErrInvalidState
+	}
+
+	return provider.Exchange(ctx, code, s.Verifier, s.Nonce)
+}
+
+// ResolveUser returns the user of an identity. Identities seen for the first
+// time are linked to the user with the same email when the provider verified
+// it, otherwise a new user is created. Emails verified by the provider count
+// as verified, for others the verification email is sent.
+func ResolveUser(provider string, identity Identity) (models.User, error) {
+	var user models.User
+
+	// Known identity
+	var link models.UserIdentities
+	if err := facades.Orm().Query().Where("provider = ? AND subject = ?", provider, identity.Subject).First(&link); err != nil {
+		return user, err
+	}
+	if link.ID != 0 {
+		if err := facades.Orm().Query().Where("id = ?", link.UserID).FirstOrFail(&user); err != nil {
+			return user, err
+		}
+		if link.Email != identity.Email {
+			if _, err := facades.Orm().Query().Model(&link).Update("email", identity.Email); err != nil {
+				return user, err
+			}
+		}
+		return user, verify(&user, identity)
+	}
+
+	if err := facades.Orm().Query().Where("email = ?", identity.Email).First(&user); err != nil {
+		return user, err
+	}
+
+	// Only a verified email proves the identity belongs to the existing user
+	if user.ID != 0 && !identity.EmailVerified {
+		return models.User{}, ErrEmailTaken
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return user, err
+	}
+
+	verificationToken := ""
+	if user.ID == 0 {
+		if user, verificationToken, err = newUser(identity); err != nil {
+			tx.Rollback()
+			return user, err
+		}
+		if err := tx.Create(&user); err != nil {
+			tx.Rollback()
+			return user, err
+		}
+	}
+
+	if err := tx.Create(&models.UserIdentities{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	}); err != nil {
+		tx.Rollback()
+		return user, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return user, err
+	}
+
+	// The verification email is sent by the listener of the registered event
+	if verificationToken != "" {
+		if err := facades.Event().Job(&events.UserRegistered{}, []event.Arg{
+			{Type: "uint", Value: user.ID},
+			{Type: "string", Value: user.Email},
+			{Type: "string", Value: verificationToken},
+		}).Dispatch(); err != nil {
+			facades.Log().Errorf("Failed to dispatch registered event for user %d: %v", user.ID, err)
+		}
+		return user, nil
+	}
+
+	return user, verify(&user, identity)
+}
+
+// newUser creates a user for an identity. The password is random, it can be
+// set with a password reset.
+func newUser(identity Identity) (models.User, string, error) {
+	password, _, err := tokens.Generate()
+	if err != nil {
+		return models.User{}, "", err
+	}
+	hashedPass, err := facades.Hash().Make(password)
+	if err != nil {
+		return models.User{}, "", err
+	}
+
+	name := identity.Name
+	if name == "" {
+		name, _, _ = strings.Cut(identity.Email, "@")
+	}
+
+	now := time.Now()
+	user := models.User{
+		Name:     name,
+		Email:    identity.Email,
+		Password: hashedPass,
+	}
+	if identity.EmailVerified {
+		user.EmailVerifiedAt = &now
+		return user, "", nil
+	}
+
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return models.User{}, "", err
+	}
+	expiresAt := now.Add(time.Duration(facades.Config().GetInt("auth.verification.expire", 1440)) * time.Minute)
+	user.VerificationToken = tokenHash
+	user.VerificationExpiresAt = &expiresAt
+	user.VerificationSentAt = &now
+
+	return user, token, nil
+}
+
+// verify marks the email of the user as verified when the provider verified it
+func verify(user *models.User, identity Identity) error {
+	if user.EmailVerifiedAt != nil || !identity.EmailVerified || identity.Email != user.Email {
+		return nil
+	}
+
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]any{
+		"email_verified_at":       now,
+		"verification_token":      "",
+		"verification_expires_at": nil,
+	}); err != nil {
+		return err
+	}
+	users.Forget(user.ID)
+	user.EmailVerifiedAt = &now
+
+	return nil
+}
+
+func stateKey(value string) string {
+	return "auth:oidc_state:" + value
+}
+
+func stateTTL() time.Duration {
+	return time.Duration(facades.Config().GetInt("oidc.state_ttl", 10)) * time.Minute
+}
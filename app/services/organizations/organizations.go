@@ -0,0 +1,236 @@
+package organizations
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+var (
+	ErrNotFound      = errors.New("the organization does not exist")
+	ErrSlugTaken     = errors.New("the slug is already taken")
+	ErrInvalidSlug   = errors.New("the slug must contain letters or digits")
+	ErrUserNotFound  = errors.New("no user has this email")
+	ErrAlreadyMember = errors.New("the user is already a member of the organization")
+	ErrNotMember     = errors.New("the user is not a member of the organization")
+	ErrLastOwner     = errors.New("the organization needs at least one owner")
+)
+
+// Roles are the roles that can be given to members
+var Roles = []models.OrganizationRole{models.OrganizationOwner, models.OrganizationAdmin, models.OrganizationMember}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slugify turns a name into the slug of an organization, e.g. "Human
+// Resources" into "human-resources"
+func Slugify(name string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// Find returns the organization with the given id or slug
+func Find(idOrSlug string) (models.Organizations, error) {
+	var organization models.Organizations
+	query := facades.Orm().Query()
+	if id, err := strconv.ParseUint(idOrSlug, 10, 64); err == nil {
+		query = query.Where("id = ?", id)
+	} else {
+		query = query.Where("slug = ?", strings.ToLower(idOrSlug))
+	}
+	if err := query.First(&organization); err != nil {
+		return models.Organizations{}, err
+	}
+	if organization.ID == 0 {
+		return models.Organizations{}, ErrNotFound
+	}
+
+	return organization, nil
+}
+
+// RoleOf returns the role of the user in the organization, empty when the user
+// is not a member
+func RoleOf(organizationID, userID uint) (models.OrganizationRole, error) {
+	var member models.OrganizationMembers
+	if err := facades.Orm().Query().Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member); err != nil {
+		return "", err
+	}
+
+	return member.Role, nil
+}
+
+// ForUser returns the organizations the user is a member of with the role of
+// the user in each
+func ForUser(userID uint) ([]models.OrganizationResponse, error) {
+	var members []models.OrganizationMembers
+	if err := facades.Orm().Query().Where("user_id = ?", userID).OrderBy("organization_id").Find(&members); err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.OrganizationResponse, 0, len(members))
+	for _, member := range members {
+		var organization models.Organizations
+		if err := facades.Orm().Query().Where("id = ?", member.OrganizationID).First(&organization); err != nil {
+			return nil, err
+		}
+		resp = append(resp, organization.ToResponse(member.Role))
+	}
+
+	return resp, nil
+}
+
+// Create creates an organization owned by the user, the slug is derived from
+// the name when empty
+func Create(name, slug string, ownerID uint) (models.Organizations, error) {
+	if slug == "" {
+		slug = Slugify(name)
+	}
+	slug = strings.ToLower(slug)
+	if slug == "" {
+		return models.Organizations{}, ErrInvalidSlug
+	}
+
+	var exists bool
+	if err := facades.Orm().Query().Model(&models.Organizations{}).Where("slug = ?", slug).Exists(&exists); err != nil {
+		return models.Organizations{}, err
+	}
+	if exists {
+		return models.Organizations{}, ErrSlugTaken
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return models.Organizations{}, err
+	}
+	organization := models.Organizations{Name: name, Slug: slug}
+	if err := tx.Create(&organization); err != nil {
+		tx.Rollback()
+		return models.Organizations{}, err
+	}
+	if err := tx.Create(&models.OrganizationMembers{
+		OrganizationID: organization.ID,
+		UserID:         ownerID,
+		Role:           models.OrganizationOwner,
+	}); err != nil {
+		tx.Rollback()
+		return models.Organizations{}, err
+	}
+
+	return organization, tx.Commit()
+}
+
+// Members returns the members of the organization
+func Members(organizationID uint) ([]models.OrganizationMemberResponse, error) {
+	var members []models.OrganizationMembers
+	if err := facades.Orm().Query().Where("organization_id = ?", organizationID).OrderBy("id").Find(&members); err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.OrganizationMemberResponse, 0, len(members))
+	for _, member := range members {
+		var user models.User
+		if err := facades.Orm().Query().Where("id = ?", member.UserID).First(&user); err != nil {
+			return nil, err
+		}
+		resp = append(resp, models.OrganizationMemberResponse{
+			UserID: int(user.ID),
+			Name:   user.Name,
+			Email:  user.Email,
+			Role:   member.Role,
+		})
+	}
+
+	return resp, nil
+}
+
+// AddMember adds the user with the email to the organization
+func AddMember(organizationID uint, email string, role models.OrganizationRole) (models.OrganizationMemberResponse, error) {
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", strings.ToLower(strings.TrimSpace(email))).First(&user); err != nil {
+		return models.OrganizationMemberResponse{}, err
+	}
+	if user.ID == 0 {
+		return models.OrganizationMemberResponse{}, ErrUserNotFound
+	}
+
+	current, err := RoleOf(organizationID, user.ID)
+	if err != nil {
+		return models.OrganizationMemberResponse{}, err
+	}
+	if current != "" {
+		return models.OrganizationMemberResponse{}, ErrAlreadyMember
+	}
+
+	if err := facades.Orm().Query().Create(&models.OrganizationMembers{
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Role:           role,
+	}); err != nil {
+		return models.OrganizationMemberResponse{}, err
+	}
+
+	return models.OrganizationMemberResponse{
+		UserID: int(user.ID),
+		Name:   user.Name,
+		Email:  user.Email,
+		Role:   role,
+	}, nil
+}
+
+// UpdateMember changes the role of a member, the last owner cannot be demoted
+func UpdateMember(organizationID, userID uint, role models.OrganizationRole) error {
+	current, err := RoleOf(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrNotMember
+	}
+	if current == models.OrganizationOwner && role != models.OrganizationOwner {
+		if err := ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	_, err = facades.Orm().Query().Model(&models.OrganizationMembers{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Update("role", role)
+	return err
+}
+
+// RemoveMember removes a member from the organization, the last owner cannot
+// be removed. Polls the member created stay in the organization.
+func RemoveMember(organizationID, userID uint) error {
+	current, err := RoleOf(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if current == "" {
+		return ErrNotMember
+	}
+	if current == models.OrganizationOwner {
+		if err := ensureAnotherOwner(organizationID); err != nil {
+			return err
+		}
+	}
+
+	_, err = facades.Orm().Query().Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&models.OrganizationMembers{})
+	return err
+}
+
+func ensureAnotherOwner(organizationID uint) error {
+	var owners int64
+	if err := facades.Orm().Query().Model(&models.OrganizationMembers{}).
+		Where("organization_id = ? AND role = ?", organizationID, models.OrganizationOwner).
+		Count(&owners); err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+
+	return nil
+}
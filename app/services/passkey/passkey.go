@@ -0,0 +1,273 @@
+package passkey
+
+import (
+	"crypto/rand"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+const (
+	ceremonyRegistration = "registration"
+	ceremonyLogin        = "login"
+)
+
+var (
+	ErrInvalidChallenge  = errors.New("the passkey challenge is invalid or expired")
+	ErrUnknownCredential = errors.New("the passkey is not registered")
+	ErrDuplicateKey      = errors.New("the passkey is already registered")
+)
+
+// RP returns the relying party of the webauthn config
+func RP() RelyingParty {
+	var origins []string
+	for _, origin := range strings.Split(facades.Config().GetString("webauthn.origins"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	return RelyingParty{
+		ID:      facades.Config().GetString("webauthn.rp_id", "localhost"),
+		Origins: origins,
+	}
+}
+
+// UserHandle is the id of the user known to authenticators
+func UserHandle(userID uint) string {
+	return Encode([]byte(strconv.FormatUint(uint64(userID), 10)))
+}
+
+// RegistrationOptions starts the registration of a passkey for the user. The
+// passkeys the user already has are excluded so an authenticator is not
+// registered twice.
+func RegistrationOptions(user models.User) (models.PasskeyCreationOptions, error) {
+	challenge, err := newChallenge(ceremonyRegistration, user.ID)
+	if err != nil {
+		return models.PasskeyCreationOptions{}, err
+	}
+
+	exclude, err := descriptors(user.ID)
+	if err != nil {
+		return models.PasskeyCreationOptions{}, err
+	}
+
+	return models.PasskeyCreationOptions{
+		Challenge: challenge,
+		RP: models.PasskeyRelyingParty{
+			ID:   RP().ID,
+			Name: facades.Config().GetString("webauthn.rp_name", "Evote"),
+		},
+		User: models.PasskeyUser{
+			ID:          UserHandle(user.ID),
+			Name:        user.Email,
+			DisplayName: user.Name,
+		},
+		PubKeyCredParams: []models.PasskeyCredentialParameter{
+			{Type: "public-key", Alg: AlgES256},
+			{Type: "public-key", Alg: AlgEdDSA},
+			{Type: "public-key", Alg: AlgRS256},
+		},
+		Timeout:            timeout() * 1000,
+		Attestation:        "none",
+		ExcludeCredentials: exclude,
+		AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+	}, nil
+}
+
+// Register checks the response of the authenticator to a registration of the
+// user and stores the new passkey
+func Register(userID uint, name string, resp AttestationResponse) (models.PasskeyCredentials, error) {
+	challenge, challengeUserID, err := consumeChallenge(ceremonyRegistration, resp.ClientDataJSON)
+	if err != nil {
+		return models.PasskeyCredentials{}, err
+	}
+	if challengeUserID != userID {
+		return models.PasskeyCredentials{}, ErrInvalidChallenge
+	}
+
+	credential, err := RP().VerifyRegistration(challenge, resp)
+	if err != nil {
+		return models.PasskeyCredentials{}, err
+	}
+
+	var exists bool
+	if err := facades.Orm().Query().Model(&models.PasskeyCredentials{}).
+		Where("credential_id = ?", Encode(credential.ID)).Exists(&exists); err != nil {
+		return models.PasskeyCredentials{}, err
+	}
+	if exists {
+		return models.PasskeyCredentials{}, ErrDuplicateKey
+	}
+
+	if name == "" {
+		name = "Passkey"
+	}
+	passkey := models.PasskeyCredentials{
+		UserID:       userID,
+		CredentialID: Encode(credential.ID),
+		PublicKey:    Encode(credential.PublicKey),
+		SignCount:    credential.SignCount,
+		Name:         name,
+	}
+	if err := facades.Orm().Query().Create(&passkey); err != nil {
+		return models.PasskeyCredentials{}, err
+	}
+
+	return passkey, nil
+}
+
+// LoginOptions starts a login with a passkey. Without an email the browser
+// offers the passkeys it knows for the site. The options look the same whether
+// the email is registered or not.
+func LoginOptions(email string) (models.PasskeyRequestOptions, error) {
+	var user models.User
+	if email != "" {
+		if err := facades.Orm().Query().Where("email = ?", email).First(&user); err != nil {
+			return models.PasskeyRequestOptions{}, err
+		}
+	}
+
+	challenge, err := newChallenge(ceremonyLogin, user.ID)
+	if err != nil {
+		return models.PasskeyRequestOptions{}, err
+	}
+
+	allow := []models.PasskeyCredentialDescriptor{}
+	if user.ID != 0 {
+		if allow, err = descriptors(user.ID); err != nil {
+			return models.PasskeyRequestOptions{}, err
+		}
+	}
+
+	return models.PasskeyRequestOptions{
+		Challenge:        challenge,
+		RPID:             RP().ID,
+		Timeout:          timeout() * 1000,
+		AllowCredentials: allow,
+		UserVerification: "preferred",
+	}, nil
+}
+
+// Login checks the response of the authenticator to a login and returns the
+// passkey that was used
+func Login(credentialID string, resp AssertionResponse) (models.PasskeyCredentials, Assertion, error) {
+	challenge, challengeUserID, err := consumeChallenge(ceremonyLogin, resp.ClientDataJSON)
+	if err != nil {
+		return models.PasskeyCredentials{}, Assertion{}, err
+	}
+
+	var passkey models.PasskeyCredentials
+	if err := facades.Orm().Query().Where("credential_id = ?", strings.TrimRight(credentialID, "=")).First(&passkey); err != nil {
+		return models.PasskeyCredentials{}, Assertion{}, err
+	}
+	if passkey.ID == 0 {
+		return models.PasskeyCredentials{}, Assertion{}, ErrUnknownCredential
+	}
+
+	// A challenge for an email can only be answered with a passkey of that user
+	if challengeUserID != 0 && challengeUserID != passkey.UserID {
+		return models.PasskeyCredentials{}, Assertion{}, ErrUnknownCredential
+	}
+	if resp.UserHandle != "" && strings.TrimRight(resp.UserHandle, "=") != UserHandle(passkey.UserID) {
+		return models.PasskeyCredentials{}, Assertion{}, ErrUnknownCredential
+	}
+
+	publicKey, err := Decode(passkey.PublicKey)
+	if err != nil {
+		return models.PasskeyCredentials{}, Assertion{}, err
+	}
+	assertion, err := RP().VerifyAssertion(challenge, publicKey, passkey.SignCount, resp)
+	if err != nil {
+		if errors.Is(err, ErrClonedAuthenticator) {
+			facades.Log().Warningf("Sign count of passkey %d went backwards, the authenticator may be cloned", passkey.ID)
+		}
+		return models.PasskeyCredentials{}, Assertion{}, err
+	}
+
+	// The condition rejects a concurrent login that already stored a newer count
+	now := time.Now()
+	result, err := facades.Orm().Query().Model(&models.PasskeyCredentials{}).
+		Where("id = ? AND sign_count = ?", passkey.ID, passkey.SignCount).
+		Update(map[string]any{
+			"sign_count":   assertion.SignCount,
+			"last_used_at": now,
+		})
+	if err != nil {
+		return models.PasskeyCredentials{}, Assertion{}, err
+	}
+	if result.RowsAffected == 0 {
+		return models.PasskeyCredentials{}, Assertion{}, ErrClonedAuthenticator
+	}
+
+	passkey.SignCount = assertion.SignCount
+	passkey.LastUsedAt = &now
+	return passkey, assertion, nil
+}
+
+func descriptors(userID uint) ([]models.PasskeyCredentialDescriptor, error) {
+	var ids []string
+	if err := facades.Orm().Query().Model(&models.PasskeyCredentials{}).
+		Where("user_id = ?", userID).Pluck("credential_id", &ids); err != nil {
+		return nil, err
+	}
+
+	resp := make([]models.PasskeyCredentialDescriptor, len(ids))
+	for i, id := range ids {
+		resp[i] = models.PasskeyCredentialDescriptor{Type: "public-key", ID: id}
+	}
+
+	return resp, nil
+}
+
+// newChallenge creates a random challenge for a ceremony of the user, zero
+// when the user is not known yet
+func newChallenge(ceremony string, userID uint) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	challenge := Encode(b)
+	if err := facades.Cache().Put(challengeKey(ceremony, challenge), userID, time.Duration(timeout())*time.Second); err != nil {
+		return "", err
+	}
+
+	return challenge, nil
+}
+
+// consumeChallenge returns the challenge the client data was signed for and
+// the user it was created for. A challenge is accepted once.
+func consumeChallenge(ceremony, clientDataJSON string) (string, uint, error) {
+	clientData, _, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return "", 0, err
+	}
+
+	key := challengeKey(ceremony, clientData.Challenge)
+	if !facades.Cache().Add(key+":used", true, time.Duration(timeout())*time.Second) {
+		return "", 0, ErrInvalidChallenge
+	}
+	userID, ok := facades.Cache().Pull(key).(uint)
+	if !ok {
+		return "", 0, ErrInvalidChallenge
+	}
+
+	return clientData.Challenge, userID, nil
+}
+
+func challengeKey(ceremony, challenge string) string {
+	return "auth:passkey_challenge:" + ceremony + ":" + challenge
+}
+
+func timeout() int {
+	return facades.Config().GetInt("webauthn.timeout", 300)
+}
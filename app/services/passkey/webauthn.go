@@ -0,0 +1,361 @@
+package passkey
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// Flags of the authenticator data
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+// COSE algorithms supported for credentials
+const (
+	AlgES256 = -7
+	AlgEdDSA = -8
+	AlgRS256 = -257
+)
+
+var (
+	ErrInvalidResponse      = errors.New("the passkey response is invalid")
+	ErrUnsupportedKey       = errors.New("the passkey uses an unsupported algorithm")
+	ErrInvalidSignature     = errors.New("the passkey signature is invalid")
+	ErrClonedAuthenticator  = errors.New("the passkey sign count went backwards, the authenticator may be cloned")
+	ErrChallengeMismatch    = errors.New("the passkey response is for another challenge")
+	ErrOriginNotAllowed     = errors.New("the passkey response comes from an origin that is not allowed")
+	ErrRelyingPartyMismatch = errors.New("the passkey was created for another relying party")
+)
+
+var cbor = &codec.CborHandle{BasicHandle: codec.BasicHandle{DecodeOptions: codec.DecodeOptions{SignedInteger: true}}}
+
+// RelyingParty checks authenticator responses for the domain passkeys are bound to
+type RelyingParty struct {
+	ID      string
+	Origins []string
+}
+
+// ClientData is the data the browser passed to the authenticator
+type ClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// AttestationResponse is the response of navigator.credentials.create() with
+// binary fields encoded as base64url
+type AttestationResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AttestationObject string `json:"attestationObject"`
+}
+
+// AssertionResponse is the response of navigator.credentials.get() with
+// binary fields encoded as base64url
+type AssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle"`
+}
+
+// Assertion is the result of a verified login
+type Assertion struct {
+	SignCount uint32
+	// UserVerified is set when the authenticator checked a PIN or biometric
+	UserVerified bool
+}
+
+// Credential is a public key credential created by an authenticator, the
+// public key is kept COSE encoded
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	SignCount uint32
+}
+
+type authenticatorData struct {
+	flags     byte
+	signCount uint32
+	// attested is the attested credential data, present on registration only
+	attested []byte
+}
+
+// Encode encodes binary WebAuthn values as base64url without padding
+func Encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode decodes base64url values with or without padding
+func Decode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+// ParseClientData decodes the client data and returns it together with its raw JSON
+func ParseClientData(encoded string) (ClientData, []byte, error) {
+	raw, err := Decode(encoded)
+	if err != nil {
+		return ClientData{}, nil, ErrInvalidResponse
+	}
+
+	var clientData ClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return ClientData{}, nil, ErrInvalidResponse
+	}
+
+	return clientData, raw, nil
+}
+
+// VerifyRegistration checks the response of a registration for the challenge
+// and returns the new credential. Attestation statements are not verified, as
+// only "none" attestation is requested.
+func (rp RelyingParty) VerifyRegistration(challenge string, resp AttestationResponse) (Credential, error) {
+	clientData, _, err := ParseClientData(resp.ClientDataJSON)
+	if err != nil {
+		return Credential{}, err
+	}
+	if err := rp.checkClientData(clientData, "webauthn.create", challenge); err != nil {
+		return Credential{}, err
+	}
+
+	raw, err := Decode(resp.AttestationObject)
+	if err != nil {
+		return Credential{}, ErrInvalidResponse
+	}
+	var object map[string]any
+	if err := codec.NewDecoderBytes(raw, cbor).Decode(&object); err != nil {
+		return Credential{}, ErrInvalidResponse
+	}
+	rawAuthData, ok := object["authData"].([]byte)
+	if !ok {
+		return Credential{}, ErrInvalidResponse
+	}
+
+	data, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return Credential{}, err
+	}
+	if data.flags&flagAttested == 0 || len(data.attested) < 18 {
+		return Credential{}, ErrInvalidResponse
+	}
+
+	// aaguid (16) | credential id length (2) | credential id | COSE key
+	idLength := int(binary.BigEndian.Uint16(data.attested[16:18]))
+	if len(data.attested) < 18+idLength {
+		return Credential{}, ErrInvalidResponse
+	}
+	id := data.attested[18 : 18+idLength]
+	rest := data.attested[18+idLength:]
+
+	// The key is followed by extensions, only the first item is the key
+	var key map[any]any
+	decoder := codec.NewDecoderBytes(rest, cbor)
+	if err := decoder.Decode(&key); err != nil {
+		return Credential{}, ErrInvalidResponse
+	}
+	publicKey := rest[:decoder.NumBytesRead()]
+	if _, _, err := parsePublicKey(publicKey); err != nil {
+		return Credential{}, err
+	}
+
+	return Credential{
+		ID:        bytes.Clone(id),
+		PublicKey: bytes.Clone(publicKey),
+		SignCount: data.signCount,
+	}, nil
+}
+
+// VerifyAssertion checks the response of a login for the challenge with the
+// stored public key and sign count of the credential
+func (rp RelyingParty) VerifyAssertion(challenge string, publicKey []byte, signCount uint32, resp AssertionResponse) (Assertion, error) {
+	clientData, clientDataJSON, err := ParseClientData(resp.ClientDataJSON)
+	if err != nil {
+		return Assertion{}, err
+	}
+	if err := rp.checkClientData(clientData, "webauthn.get", challenge); err != nil {
+		return Assertion{}, err
+	}
+
+	rawAuthData, err := Decode(resp.AuthenticatorData)
+	if err != nil {
+		return Assertion{}, ErrInvalidResponse
+	}
+	data, err := rp.parseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return Assertion{}, err
+	}
+
+	signature, err := Decode(resp.Signature)
+	if err != nil {
+		return Assertion{}, ErrInvalidResponse
+	}
+
+	// The signature covers the authenticator data and the hash of the client data
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(bytes.Clone(rawAuthData), clientDataHash[:]...)
+	if err := verifySignature(publicKey, signed, signature); err != nil {
+		return Assertion{}, err
+	}
+
+	// Authenticators without a counter always report zero
+	if (data.signCount != 0 || signCount != 0) && data.signCount <= signCount {
+		return Assertion{}, ErrClonedAuthenticator
+	}
+
+	return Assertion{
+		SignCount:    data.signCount,
+		UserVerified: data.flags&flagUserVerified != 0,
+	}, nil
+}
+
+func (rp RelyingParty) checkClientData(clientData ClientData, ceremony, challenge string) error {
+	if clientData.Type != ceremony {
+		return ErrInvalidResponse
+	}
+	if subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return ErrChallengeMismatch
+	}
+	if !slices.Contains(rp.Origins, clientData.Origin) {
+		return ErrOriginNotAllowed
+	}
+
+	return nil
+}
+
+func (rp RelyingParty) parseAuthenticatorData(raw []byte) (authenticatorData, error) {
+	// rp id hash (32) | flags (1) | sign count (4) | attested credential data
+	if len(raw) < 37 {
+		return authenticatorData{}, ErrInvalidResponse
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(raw[:32], rpIDHash[:]) != 1 {
+		return authenticatorData{}, ErrRelyingPartyMismatch
+	}
+
+	data := authenticatorData{
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+		attested:  raw[37:],
+	}
+	if data.flags&flagUserPresent == 0 {
+		return authenticatorData{}, ErrInvalidResponse
+	}
+
+	return data, nil
+}
+
+func verifySignature(publicKey, signed, signature []byte) error {
+	key, alg, err := parsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(signed)
+	valid := false
+	switch alg {
+	case AlgES256:
+		valid = ecdsa.VerifyASN1(key.(*ecdsa.PublicKey), digest[:], signature)
+	case AlgRS256:
+		valid = rsa.VerifyPKCS1v15(key.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	case AlgEdDSA:
+		valid = ed25519.Verify(key.(ed25519.PublicKey), signed, signature)
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// parsePublicKey decodes a COSE encoded public key
+func parsePublicKey(raw []byte) (crypto.PublicKey, int64, error) {
+	var key map[any]any
+	if err := codec.NewDecoderBytes(raw, cbor).Decode(&key); err != nil {
+		return nil, 0, ErrInvalidResponse
+	}
+
+	alg, _ := coseInt(key, 3)
+	switch alg {
+	case AlgES256:
+		crv, _ := coseInt(key, -1)
+		x, xok := coseBytes(key, -2)
+		y, yok := coseBytes(key, -3)
+		if crv != 1 || !xok || !yok {
+			return nil, 0, ErrUnsupportedKey
+		}
+
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, ErrInvalidResponse
+		}
+		return pub, alg, nil
+	case AlgRS256:
+		n, nok := coseBytes(key, -1)
+		e, eok := coseBytes(key, -2)
+		if !nok || !eok || len(e) > 4 {
+			return nil, 0, ErrUnsupportedKey
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	case AlgEdDSA:
+		crv, _ := coseInt(key, -1)
+		x, ok := coseBytes(key, -2)
+		if crv != 6 || !ok || len(x) != ed25519.PublicKeySize {
+			return nil, 0, ErrUnsupportedKey
+		}
+
+		return ed25519.PublicKey(x), alg, nil
+	}
+
+	return nil, 0, ErrUnsupportedKey
+}
+
+func coseValue(key map[any]any, label int64) any {
+	for k, v := range key {
+		switch k := k.(type) {
+		case int64:
+			if k == label {
+				return v
+			}
+		case uint64:
+			if label >= 0 && k == uint64(label) {
+				return v
+			}
+		}
+	}
+
+	return nil
+}
+
+func coseInt(key map[any]any, label int64) (int64, bool) {
+	switch v := coseValue(key, label).(type) {
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	}
+
+	return 0, false
+}
+
+func coseBytes(key map[any]any, label int64) ([]byte, bool) {
+	v, ok := coseValue(key, label).([]byte)
+	return v, ok
+}
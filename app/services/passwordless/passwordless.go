@@ -0,0 +1,146 @@
+package passwordless
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+)
+
+var ErrInvalidToken = errors.New("the login link or code is invalid or expired")
+
+// GenerateCode returns a random 6-digit code
+func GenerateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// HashCode returns the hash of a code as stored for the user. Codes are short,
+// the user id keeps equal codes of different users apart.
+func HashCode(userID uint, code string) string {
+	return tokens.Hash(fmt.Sprintf("%d:%s", userID, code))
+}
+
+// Send emails a login link and code to the user, replacing the ones sent before
+func Send(user models.User) error {
+	token, tokenHash, err := tokens.Generate()
+	if err != nil {
+		return err
+	}
+	code, err := GenerateCode()
+	if err != nil {
+		return err
+	}
+
+	// Only the latest link and code work
+	if _, err := facades.Orm().Query().Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.LoginTokens{}); err != nil {
+		return err
+	}
+	if err := facades.Orm().Query().Create(&models.LoginTokens{
+		UserID:    user.ID,
+		Token:     tokenHash,
+		Code:      HashCode(user.ID, code),
+		ExpiresAt: time.Now().Add(time.Duration(facades.Config().GetInt("auth.passwordless.expire", 10)) * time.Minute),
+	}); err != nil {
+		return err
+	}
+
+	// The email is queued by the listener of the event
+	return facades.Event().Job(&events.LoginLinkRequested{}, []event.Arg{
+		{Type: "uint", Value: user.ID},
+		{Type: "string", Value: user.Email},
+		{Type: "string", Value: token},
+		{Type: "string", Value: code},
+	}).Dispatch()
+}
+
+// LoginWithToken uses the token of a login link and returns the user to sign in
+func LoginWithToken(token string) (uint, error) {
+	var login models.LoginTokens
+	if err := facades.Orm().Query().
+		Where("token = ? AND used_at IS NULL AND expires_at > ?", tokens.Hash(token), time.Now()).
+		First(&login); err != nil {
+		return 0, err
+	}
+	if login.ID == 0 {
+		return 0, ErrInvalidToken
+	}
+
+	return use(login)
+}
+
+// LoginWithCode uses the code sent to the email and returns the user to sign
+// in. A code can be tried max_attempts times.
+func LoginWithCode(email, code string) (uint, error) {
+	var user models.User
+	if err := facades.Orm().Query().Where("email = ?", email).First(&user); err != nil {
+		return 0, err
+	}
+	if user.ID == 0 {
+		return 0, ErrInvalidToken
+	}
+
+	maxAttempts := facades.Config().GetInt("auth.passwordless.max_attempts", 5)
+	var login models.LoginTokens
+	if err := facades.Orm().Query().
+		Where("user_id = ? AND used_at IS NULL AND expires_at > ? AND attempts < ?", user.ID, time.Now(), maxAttempts).
+		First(&login); err != nil {
+		return 0, err
+	}
+	if login.ID == 0 {
+		return 0, ErrInvalidToken
+	}
+
+	// Every try counts, the condition bounds the tries of concurrent requests
+	result, err := facades.Orm().Query().Exec("UPDATE login_tokens SET attempts = attempts + 1 WHERE id = ? AND attempts < ?", login.ID, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected == 0 || subtle.ConstantTimeCompare([]byte(login.Code), []byte(HashCode(user.ID, code))) != 1 {
+		return 0, ErrInvalidToken
+	}
+
+	return use(login)
+}
+
+// use marks a login as used and verifies the email of the user, who just
+// proved to own it. The condition keeps a login single-use when requests race.
+func use(login models.LoginTokens) (uint, error) {
+	now := time.Now()
+	result, err := facades.Orm().Query().Model(&models.LoginTokens{}).
+		Where("id = ? AND used_at IS NULL", login.ID).
+		Update("used_at", now)
+	if err != nil {
+		return 0, err
+	}
+	if result.RowsAffected == 0 {
+		return 0, ErrInvalidToken
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.User{}).
+		Where("id = ? AND email_verified_at IS NULL", login.UserID).
+		Update(map[string]any{
+			"email_verified_at":       now,
+			"verification_token":      "",
+			"verification_expires_at": nil,
+		}); err != nil {
+		return 0, err
+	}
+	users.Forget(login.UserID)
+
+	return login.UserID, nil
+}
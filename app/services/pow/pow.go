@@ -0,0 +1,122 @@
+package pow
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/facades"
+)
+
+var ErrInvalidSolution = errors.New("the proof of work is invalid or expired")
+
+// Challenge is a puzzle for a client to solve
+type Challenge struct {
+	Challenge  string    `json:"challenge"`
+	Difficulty int       `json:"difficulty"`
+	Algorithm  string    `json:"algorithm" example:"sha256"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Enabled reports whether guarded endpoints require a proof of work
+func Enabled() bool {
+	return facades.Config().GetBool("proof_of_work.enabled", false)
+}
+
+// Issue creates a challenge for the client, harder the more requests the
+// client sent within the last minute
+func Issue(ip string) (Challenge, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Challenge{}, err
+	}
+
+	expire := time.Duration(facades.Config().GetInt("proof_of_work.expire", 120)) * time.Second
+	challenge := Challenge{
+		Challenge:  hex.EncodeToString(b),
+		Difficulty: Difficulty(Hit(ip)),
+		Algorithm:  "sha256",
+		ExpiresAt:  time.Now().Add(expire),
+	}
+	if err := facades.Cache().Put(challengeKey(ip, challenge.Challenge), challenge.Difficulty, expire); err != nil {
+		return Challenge{}, err
+	}
+
+	return challenge, nil
+}
+
+// Verify uses up the challenge of the client and checks the nonce solves it
+func Verify(ip, challenge, nonce string) error {
+	if challenge == "" || nonce == "" {
+		return ErrInvalidSolution
+	}
+
+	// Pulling makes a challenge single-use
+	difficulty, err := strconv.Atoi(fmt.Sprint(facades.Cache().Pull(challengeKey(ip, challenge))))
+	if err != nil || !Solves(challenge, nonce, difficulty) {
+		return ErrInvalidSolution
+	}
+
+	return nil
+}
+
+// Hit counts a request of the client and returns its requests within the
+// current minute. The count is a heuristic, concurrent requests may be missed.
+func Hit(ip string) int {
+	key := "pow:rate:" + ip + ":" + strconv.FormatInt(time.Now().Unix()/60, 10)
+	count := facades.Cache().GetInt(key) + 1
+	if err := facades.Cache().Put(key, count, 2*time.Minute); err != nil {
+		facades.Log().Errorf("Failed to count proof of work requests: %v", err)
+	}
+
+	return count
+}
+
+// Difficulty returns the zero bits required from a client with the given
+// number of requests within the last minute
+func Difficulty(requests int) int {
+	difficulty := facades.Config().GetInt("proof_of_work.difficulty", 16)
+	if step := facades.Config().GetInt("proof_of_work.step", 30); step > 0 {
+		difficulty += requests / step
+	}
+
+	return min(difficulty, facades.Config().GetInt("proof_of_work.max_difficulty", 24))
+}
+
+// Solves reports whether the SHA-256 hash of "challenge:nonce" starts with
+// difficulty zero bits
+func Solves(challenge, nonce string, difficulty int) bool {
+	sum := sha256.Sum256([]byte(challenge + ":" + nonce))
+	return LeadingZeroBits(sum[:]) >= difficulty
+}
+
+// Solve finds a nonce for a challenge, as clients do
+func Solve(challenge string, difficulty int) string {
+	for nonce := 0; ; nonce++ {
+		if candidate := strconv.Itoa(nonce); Solves(challenge, candidate, difficulty) {
+			return candidate
+		}
+	}
+}
+
+// LeadingZeroBits returns the number of zero bits the hash starts with
+func LeadingZeroBits(hash []byte) int {
+	count := 0
+	for _, b := range hash {
+		if b != 0 {
+			return count + bits.LeadingZeros8(b)
+		}
+		count += 8
+	}
+
+	return count
+}
+
+func challengeKey(ip, challenge string) string {
+	return "pow:challenge:" + ip + ":" + challenge
+}
@@ -0,0 +1,251 @@
+package rbac
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// Roles
+const (
+	Admin     = "admin"
+	Organizer = "organizer"
+	Voter     = "voter"
+	Auditor   = "auditor"
+)
+
+// Permissions, abilities of the gate check them together with ownership
+const (
+	PollCreate    = "poll.create"
+	PollUpdate    = "poll.update"
+	PollUpdateAny = "poll.update.any"
+	PollDelete    = "poll.delete"
+	PollDeleteAny = "poll.delete.any"
+	PollViewAny   = "poll.view.any"
+	VoteCast      = "vote.cast"
+	WebhookManage = "webhook.manage"
+	RoleManage    = "role.manage"
+
+	LoginAttemptView = "login_attempt.view"
+)
+
+// permissionsTTL bounds how long a change of the permissions of a role takes
+// to apply to its users
+const permissionsTTL = time.Minute
+
+var ErrUnknownRole = errors.New("the role does not exist")
+
+// Role is a role with its permissions as seeded
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// DefaultPermissions are the permissions known to the application
+var DefaultPermissions = map[string]string{
+	PollCreate:    "Create polls",
+	PollUpdate:    "Edit, publish and close own polls",
+	PollUpdateAny: "Edit, publish and close any poll",
+	PollDelete:    "Delete own polls",
+	PollDeleteAny: "Delete any poll",
+	PollViewAny:   "View any poll with its options and history",
+	VoteCast:      "Vote in polls",
+	WebhookManage: "Manage webhooks of own polls",
+	RoleManage:    "Assign roles to users",
+
+	LoginAttemptView: "Read the login attempt log",
+}
+
+// DefaultRoles are the roles seeded by the RoleSeeder
+var DefaultRoles = []Role{
+	{
+		Name:        Admin,
+		Description: "Manages every poll and the roles of users",
+		Permissions: []string{PollCreate, PollUpdate, PollUpdateAny, PollDelete, PollDeleteAny, PollViewAny, VoteCast, WebhookManage, RoleManage, LoginAttemptView},
+	},
+	{
+		Name:        Organizer,
+		Description: "Runs own polls",
+		Permissions: []string{PollCreate, PollUpdate, PollDelete, VoteCast, WebhookManage},
+	},
+	{
+		Name:        Voter,
+		Description: "Votes in polls",
+		Permissions: []string{VoteCast},
+	},
+	{
+		Name:        Auditor,
+		Description: "Reads every poll and its history without changing anything",
+		Permissions: []string{PollViewAny},
+	},
+}
+
+// Can reports whether the roles of the user grant the permission. Users
+// without a role get the permissions of the default role, voter unless
+// configured otherwise.
+func Can(userID uint, permission string) bool {
+	permissions, err := Permissions(userID)
+	if err != nil {
+		facades.Log().Errorf("Failed to get permissions of user %d: %v", userID, err)
+		return false
+	}
+
+	return permissions[permission]
+}
+
+// Permissions returns the permissions the roles of the user grant
+func Permissions(userID uint) (map[string]bool, error) {
+	load := func() (map[string]bool, error) {
+		var roleIDs []uint
+		if err := facades.Orm().Query().Model(&models.UserRoles{}).Where("user_id = ?", userID).Pluck("role_id", &roleIDs); err != nil {
+			return nil, err
+		}
+		if len(roleIDs) == 0 {
+			if err := facades.Orm().Query().Model(&models.Roles{}).
+				Where("name = ?", facades.Config().GetString("auth.default_role", Voter)).
+				Pluck("id", &roleIDs); err != nil {
+				return nil, err
+			}
+		}
+
+		var names []string
+		if len(roleIDs) > 0 {
+			if err := facades.Orm().Query().Model(&models.Permissions{}).
+				Where("id IN (SELECT permission_id FROM role_permissions WHERE role_id IN ?)", roleIDs).
+				Pluck("name", &names); err != nil {
+				return nil, err
+			}
+		}
+
+		permissions := make(map[string]bool, len(names))
+		for _, name := range names {
+			permissions[name] = true
+		}
+		return permissions, nil
+	}
+
+	// The permissions are cached as JSON so that every cache store can hold them
+	value, err := facades.Cache().Remember(permissionsKey(userID), permissionsTTL, func() (any, error) {
+		permissions, err := load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(permissions)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions map[string]bool
+	if err := json.Unmarshal([]byte(fmt.Sprint(value)), &permissions); err != nil {
+		facades.Cache().Forget(permissionsKey(userID))
+		return load()
+	}
+	return permissions, nil
+}
+
+// Roles returns the names of the roles of the user
+func Roles(userID uint) ([]string, error) {
+	var names []string
+	if err := facades.Orm().Query().Model(&models.Roles{}).
+		Where("id IN (SELECT role_id FROM user_roles WHERE user_id = ?)", userID).
+		OrderBy("name").
+		Pluck("name", &names); err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// Sync replaces the roles of the user
+func Sync(userID uint, names []string) error {
+	var roles []models.Roles
+	if err := facades.Orm().Query().Where("name IN ?", names).Find(&roles); err != nil {
+		return err
+	}
+	if len(roles) != len(names) {
+		return ErrUnknownRole
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Where("user_id = ?", userID).Delete(&models.UserRoles{}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, role := range roles {
+		if err := tx.Create(&models.UserRoles{UserID: userID, RoleID: role.ID}); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	Forget(userID)
+	return nil
+}
+
+// Forget drops the cached permissions of a user after the roles changed
+func Forget(userID uint) {
+	facades.Cache().Forget(permissionsKey(userID))
+}
+
+// Seed creates the default roles and permissions that are missing, roles
+// that already exist keep their permissions
+func Seed() error {
+	permissionIDs := map[string]uint{}
+	for name, description := range DefaultPermissions {
+		var permission models.Permissions
+		if err := facades.Orm().Query().Where("name = ?", name).First(&permission); err != nil {
+			return err
+		}
+		if permission.ID == 0 {
+			permission = models.Permissions{Name: name, Description: description}
+			if err := facades.Orm().Query().Create(&permission); err != nil {
+				return err
+			}
+		}
+		permissionIDs[name] = permission.ID
+	}
+
+	for _, role := range DefaultRoles {
+		var existing models.Roles
+		if err := facades.Orm().Query().Where("name = ?", role.Name).First(&existing); err != nil {
+			return err
+		}
+		if existing.ID != 0 {
+			continue
+		}
+
+		created := models.Roles{Name: role.Name, Description: role.Description}
+		if err := facades.Orm().Query().Create(&created); err != nil {
+			return err
+		}
+		for _, permission := range role.Permissions {
+			if err := facades.Orm().Query().Create(&models.RolePermissions{RoleID: created.ID, PermissionID: permissionIDs[permission]}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func permissionsKey(userID uint) string {
+	return "auth:permissions:" + strconv.FormatUint(uint64(userID), 10)
+}
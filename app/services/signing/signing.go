@@ -0,0 +1,362 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// Algorithms keys can be generated for
+const (
+	RS256 = "RS256"
+	EdDSA = "EdDSA"
+)
+
+// keysTTL bounds how long another instance takes to verify with a key that
+// was added by a rotation
+const keysTTL = time.Minute
+
+// reloadInterval limits how often tokens with unknown key ids reload the keys
+const reloadInterval = 10 * time.Second
+
+const keysKey = "auth:signing_keys"
+
+var (
+	ErrUnsupportedAlgorithm = errors.New("the signing algorithm must be RS256 or EdDSA")
+	ErrNoKeyID              = errors.New("the token has no key id")
+	ErrUnknownKey           = errors.New("the token was signed with an unknown key")
+)
+
+// Claims of an access token, the subject is the id of the user
+type Claims struct {
+	jwt.RegisteredClaims
+}
+
+// JSONWebKey is the public part of a signing key
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is the document of /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// Generate creates a key pair to sign with
+func Generate(algorithm string) (models.SigningKeys, error) {
+	key, err := NewKey(algorithm)
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+	if err := facades.Orm().Query().Create(&key); err != nil {
+		return models.SigningKeys{}, err
+	}
+	facades.Cache().Forget(keysKey)
+
+	return key, nil
+}
+
+// NewKey returns a new key pair with the private key encrypted, without
+// storing it
+func NewKey(algorithm string) (models.SigningKeys, error) {
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case RS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case EdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return models.SigningKeys{}, ErrUnsupportedAlgorithm
+	}
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+	encrypted, err := facades.Crypt().EncryptString(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER})))
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return models.SigningKeys{}, err
+	}
+
+	return models.SigningKeys{
+		Kid:        hex.EncodeToString(b),
+		Algorithm:  algorithm,
+		PrivateKey: encrypted,
+		PublicKey:  string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})),
+	}, nil
+}
+
+// Rotate adds a key of the configured algorithm to sign with and retires the
+// keys that signed before. Retired keys are deleted once the tokens they
+// signed expired.
+func Rotate() (models.SigningKeys, error) {
+	key, err := Generate(facades.Config().GetString("jwt.algorithm", RS256))
+	if err != nil {
+		return models.SigningKeys{}, err
+	}
+
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.SigningKeys{}).
+		Where("id <> ? AND retired_at IS NULL", key.ID).
+		Update("retired_at", now); err != nil {
+		return models.SigningKeys{}, err
+	}
+
+	// Tokens that never expire keep their keys forever
+	if ttl := facades.Config().GetInt("jwt.ttl", 60); ttl > 0 {
+		if _, err := facades.Orm().Query().
+			Where("retired_at < ?", now.Add(-time.Duration(ttl)*time.Minute)).
+			Delete(&models.SigningKeys{}); err != nil {
+			return models.SigningKeys{}, err
+		}
+	}
+	facades.Cache().Forget(keysKey)
+
+	return key, nil
+}
+
+// Issue signs an access token for the user with the current key
+func Issue(userID uint) (string, Claims, error) {
+	key, private, err := current()
+	if err != nil {
+		return "", Claims{}, err
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", Claims{}, err
+	}
+
+	now := time.Now().Truncate(time.Second)
+	claims := Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        hex.EncodeToString(b),
+			Issuer:    facades.Config().GetString("jwt.issuer", "evote"),
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+	}
+	if ttl := facades.Config().GetInt("jwt.ttl", 60); ttl > 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(time.Duration(ttl) * time.Minute))
+	}
+
+	token := jwt.NewWithClaims(method(key.Algorithm), claims)
+	token.Header["kid"] = key.Kid
+	signed, err := token.SignedString(private)
+	if err != nil {
+		return "", Claims{}, err
+	}
+
+	return signed, claims, nil
+}
+
+// Parse verifies a token signed with one of the keys, a "Bearer " prefix is
+// ignored. Tokens without a key id fail with ErrNoKeyID.
+func Parse(token string) (Claims, error) {
+	var claims Claims
+	_, err := jwt.ParseWithClaims(strings.TrimPrefix(token, "Bearer "), &claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, ErrNoKeyID
+		}
+
+		key, err := find(kid)
+		if err != nil {
+			return nil, err
+		}
+		// The algorithm is bound to the key, not taken from the token
+		if token.Method.Alg() != key.Algorithm {
+			return nil, fmt.Errorf("the token is signed with %s, the key with %s", token.Method.Alg(), key.Algorithm)
+		}
+
+		return publicKey(key)
+	},
+		jwt.WithIssuer(facades.Config().GetString("jwt.issuer", "evote")),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys tokens may be signed with
+func JWKS() (JSONWebKeySet, error) {
+	keys, err := verificationKeys()
+	if err != nil {
+		return JSONWebKeySet{}, err
+	}
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range keys {
+		jwk, err := PublicJWK(key)
+		if err != nil {
+			return JSONWebKeySet{}, err
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set, nil
+}
+
+// PublicJWK returns the public part of a key as JSON Web Key
+func PublicJWK(key models.SigningKeys) (JSONWebKey, error) {
+	public, err := publicKey(key)
+	if err != nil {
+		return JSONWebKey{}, err
+	}
+
+	jwk := JSONWebKey{Use: "sig", Alg: key.Algorithm, Kid: key.Kid}
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JSONWebKey{}, ErrUnsupportedAlgorithm
+	}
+
+	return jwk, nil
+}
+
+// current returns the newest key that is not retired, the first key is
+// created on first use
+func current() (models.SigningKeys, crypto.Signer, error) {
+	var key models.SigningKeys
+	if err := facades.Orm().Query().Where("retired_at IS NULL").OrderBy("id", "desc").First(&key); err != nil {
+		return models.SigningKeys{}, nil, err
+	}
+	if key.ID == 0 {
+		generated, err := Generate(facades.Config().GetString("jwt.algorithm", RS256))
+		if err != nil {
+			return models.SigningKeys{}, nil, err
+		}
+		key = generated
+	}
+
+	signer, err := Signer(key)
+	if err != nil {
+		return models.SigningKeys{}, nil, err
+	}
+
+	return key, signer, nil
+}
+
+// Signer decrypts the private key of a key
+func Signer(key models.SigningKeys) (crypto.Signer, error) {
+	decrypted, err := facades.Crypt().DecryptString(key.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(decrypted))
+	if block == nil {
+		return nil, fmt.Errorf("the private key %s is invalid", key.Kid)
+	}
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("the private key %s cannot sign", key.Kid)
+	}
+
+	return signer, nil
+}
+
+// verificationKeys returns the keys that may have signed unexpired tokens
+func verificationKeys() ([]models.SigningKeys, error) {
+	value, err := facades.Cache().Remember(keysKey, keysTTL, func() (any, error) {
+		var keys []models.SigningKeys
+		err := facades.Orm().Query().Select("id", "kid", "algorithm", "public_key", "retired_at").OrderBy("id").Find(&keys)
+		return keys, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keys, _ := value.([]models.SigningKeys)
+	return keys, nil
+}
+
+// find returns the key with the id. Unknown ids reload the keys, as another
+// instance may have rotated, at most once per reloadInterval so that made up
+// ids can't flood the database.
+func find(kid string) (models.SigningKeys, error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		keys, err := verificationKeys()
+		if err != nil {
+			return models.SigningKeys{}, err
+		}
+		for _, key := range keys {
+			if key.Kid == kid {
+				return key, nil
+			}
+		}
+		if attempt > 0 || !facades.Cache().Add(keysKey+":reloaded", true, reloadInterval) {
+			break
+		}
+		facades.Cache().Forget(keysKey)
+	}
+
+	return models.SigningKeys{}, ErrUnknownKey
+}
+
+func publicKey(key models.SigningKeys) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(key.PublicKey))
+	if block == nil {
+		return nil, fmt.Errorf("the public key %s is invalid", key.Kid)
+	}
+
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func method(algorithm string) jwt.SigningMethod {
+	if algorithm == EdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+
+	return jwt.SigningMethodRS256
+}
@@ -0,0 +1,51 @@
+package tokens
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/services/signing"
+)
+
+// Claims of a verified access token
+type Claims struct {
+	UserID    uint
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// Parse verifies an access token. Tokens signed with the JWT secret before
+// signing keys were used are accepted until they expire.
+func Parse(ctx http.Context, token string) (Claims, error) {
+	claims, err := signing.Parse(token)
+	if errors.Is(err, signing.ErrNoKeyID) {
+		payload, err := facades.Auth(ctx).Parse(token)
+		if err != nil {
+			return Claims{}, err
+		}
+		id, err := strconv.ParseUint(payload.Key, 10, 64)
+		if err != nil {
+			return Claims{}, err
+		}
+
+		return Claims{UserID: uint(id), IssuedAt: payload.IssuedAt, ExpiresAt: payload.ExpireAt}, nil
+	}
+	if err != nil {
+		return Claims{}, err
+	}
+
+	id, err := strconv.ParseUint(claims.Subject, 10, 64)
+	if err != nil {
+		return Claims{}, err
+	}
+	parsed := Claims{UserID: uint(id), IssuedAt: claims.IssuedAt.Time}
+	if claims.ExpiresAt != nil {
+		parsed.ExpiresAt = claims.ExpiresAt.Time
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,235 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/signing"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("the refresh token is invalid or expired")
+	ErrRefreshTokenReused  = errors.New("the refresh token was already used, the session has been revoked")
+)
+
+// Pair is an access token together with the refresh token to renew it
+type Pair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+// Issue signs in the user with an access token and the first refresh token of
+// a new session on the device of the request
+func Issue(ctx http.Context, userID uint) (Pair, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Pair{}, err
+	}
+
+	now := time.Now()
+	session := models.UserSessions{
+		UserID:     userID,
+		FamilyID:   hex.EncodeToString(b),
+		UserAgent:  ctx.Request().Header("User-Agent", ""),
+		IPAddress:  ctx.Request().Ip(),
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(refreshTTL()),
+	}
+	if err := facades.Orm().Query().Create(&session); err != nil {
+		return Pair{}, err
+	}
+
+	pair, err := issue(ctx, userID, session.FamilyID)
+	if err != nil {
+		if _, err := facades.Orm().Query().Delete(&session); err != nil {
+			facades.Log().Errorf("Failed to delete session %d: %v", session.ID, err)
+		}
+		return Pair{}, err
+	}
+
+	return pair, nil
+}
+
+// Rotate exchanges a refresh token for a new pair of the same session. A token
+// that was exchanged before revokes the whole session, as it must have leaked.
+func Rotate(ctx http.Context, refreshToken string) (Pair, error) {
+	var row models.RefreshTokens
+	if err := facades.Orm().Query().Where("token = ?", Hash(refreshToken)).First(&row); err != nil {
+		return Pair{}, err
+	}
+	if row.ID == 0 || row.RevokedAt != nil || time.Now().After(row.ExpiresAt) {
+		return Pair{}, ErrInvalidRefreshToken
+	}
+	if row.UsedAt != nil {
+		if err := RevokeFamily(row.FamilyID); err != nil {
+			return Pair{}, err
+		}
+		return Pair{}, ErrRefreshTokenReused
+	}
+
+	// The condition lets only one of concurrent exchanges of the token through,
+	// the others are treated as reuse
+	result, err := facades.Orm().Query().Model(&models.RefreshTokens{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", row.ID).
+		Update("used_at", time.Now())
+	if err != nil {
+		return Pair{}, err
+	}
+	if result.RowsAffected == 0 {
+		if err := RevokeFamily(row.FamilyID); err != nil {
+			return Pair{}, err
+		}
+		return Pair{}, ErrRefreshTokenReused
+	}
+
+	// The access token issued with the exchanged one is replaced
+	if err := Revoke(row.AccessToken, row.AccessExpiresAt); err != nil {
+		return Pair{}, err
+	}
+
+	pair, err := issue(ctx, row.UserID, row.FamilyID)
+	if err != nil {
+		return Pair{}, err
+	}
+
+	// The session lives as long as its newest refresh token
+	if _, err := facades.Orm().Query().Model(&models.UserSessions{}).Where("family_id = ?", row.FamilyID).Update(map[string]any{
+		"expires_at":   time.Now().Add(refreshTTL()),
+		"last_seen_at": time.Now(),
+		"ip_address":   ctx.Request().Ip(),
+	}); err != nil {
+		return Pair{}, err
+	}
+
+	return pair, nil
+}
+
+// Logout revokes an access token and the session it belongs to
+func Logout(accessToken string, expiresAt time.Time) error {
+	hash := Hash(accessToken)
+	if err := Revoke(hash, expiresAt); err != nil {
+		return err
+	}
+
+	var row models.RefreshTokens
+	if err := facades.Orm().Query().Where("access_token = ?", hash).First(&row); err != nil {
+		return err
+	}
+	if row.ID == 0 {
+		return nil
+	}
+
+	return RevokeFamily(row.FamilyID)
+}
+
+// RevokeFamily revokes a session and every token of it
+func RevokeFamily(familyID string) error {
+	return revokeWhere("family_id = ?", familyID)
+}
+
+// RevokeUser revokes every session of a user and their tokens
+func RevokeUser(userID uint) error {
+	return revokeWhere("user_id = ?", userID)
+}
+
+// Revoke adds the hash of an access token to the revocation list until the token expires
+func Revoke(accessTokenHash string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return facades.Cache().Put(revokedKey(accessTokenHash), true, ttl)
+}
+
+// IsRevoked reports whether an access token is on the revocation list
+func IsRevoked(accessToken string) bool {
+	return facades.Cache().Has(revokedKey(Hash(accessToken)))
+}
+
+func issue(ctx http.Context, userID uint, familyID string) (Pair, error) {
+	// The random jti keeps tokens issued within the same second apart, so
+	// revoking one never revokes another session's token
+	accessToken, claims, err := signing.Issue(userID)
+	if err != nil {
+		return Pair{}, err
+	}
+	var expiresAt time.Time
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	refreshToken, hash, err := Generate()
+	if err != nil {
+		return Pair{}, err
+	}
+
+	if err := facades.Orm().Query().Create(&models.RefreshTokens{
+		UserID:          userID,
+		FamilyID:        familyID,
+		Token:           hash,
+		AccessToken:     Hash(accessToken),
+		AccessExpiresAt: expiresAt,
+		ExpiresAt:       time.Now().Add(refreshTTL()),
+	}); err != nil {
+		return Pair{}, err
+	}
+
+	return Pair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    expiresAt,
+	}, nil
+}
+
+func revokeWhere(query string, args ...any) error {
+	now := time.Now()
+
+	// Access tokens that have not expired yet go on the revocation list
+	var rows []models.RefreshTokens
+	if err := facades.Orm().Query().Where(query, args...).
+		Where("revoked_at IS NULL AND access_expires_at > ?", now).
+		Find(&rows); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := Revoke(row.AccessToken, row.AccessExpiresAt); err != nil {
+			return err
+		}
+		facades.Cache().Forget(sessionKey(row.AccessToken))
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.RefreshTokens{}).Where(query, args...).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", now); err != nil {
+		return err
+	}
+
+	_, err := facades.Orm().Query().Model(&models.UserSessions{}).Where(query, args...).
+		Where("revoked_at IS NULL").
+		Update("revoked_at", now)
+	return err
+}
+
+// refreshTTL returns how long a refresh token can be exchanged, a zero
+// refresh_ttl means it never expires like with the framework's refresh
+func refreshTTL() time.Duration {
+	ttl := facades.Config().GetInt("jwt.refresh_ttl", 20160)
+	if ttl == 0 {
+		// 100 years
+		ttl = 60 * 24 * 365 * 100
+	}
+
+	return time.Duration(ttl) * time.Minute
+}
+
+func revokedKey(accessTokenHash string) string {
+	return "auth:revoked:" + accessTokenHash
+}
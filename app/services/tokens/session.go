@@ -0,0 +1,73 @@
+package tokens
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+const (
+	// sessionCacheTTL bounds how long another instance may accept an access
+	// token after its session was revoked
+	sessionCacheTTL = time.Minute
+	// lastSeenInterval limits how often the last seen time of a session is written
+	lastSeenInterval = time.Minute
+)
+
+// Session returns the session an access token was issued for. The session is
+// empty for tokens issued before sessions were recorded.
+func Session(accessToken string) (models.UserSessions, error) {
+	hash := Hash(accessToken)
+	load := func() (models.UserSessions, error) {
+		var session models.UserSessions
+		err := facades.Orm().Query().
+			Where("family_id = (SELECT family_id FROM refresh_tokens WHERE access_token = ? LIMIT 1)", hash).
+			First(&session)
+		return session, err
+	}
+
+	// The session is cached as JSON so that every cache store can hold it
+	value, err := facades.Cache().Remember(sessionKey(hash), sessionCacheTTL, func() (any, error) {
+		session, err := load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(session)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	})
+	if err != nil {
+		return models.UserSessions{}, err
+	}
+
+	var session models.UserSessions
+	if err := json.Unmarshal([]byte(fmt.Sprint(value)), &session); err != nil {
+		facades.Cache().Forget(sessionKey(hash))
+		return load()
+	}
+	return session, nil
+}
+
+// Touch records that a session is in use, at most once per interval
+func Touch(session models.UserSessions, ip string) {
+	if !facades.Cache().Add("auth:session_seen:"+session.FamilyID, true, lastSeenInterval) {
+		return
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.UserSessions{}).Where("id = ?", session.ID).Update(map[string]any{
+		"last_seen_at": time.Now(),
+		"ip_address":   ip,
+	}); err != nil {
+		facades.Log().Errorf("Failed to update last seen time of session %d: %v", session.ID, err)
+	}
+}
+
+func sessionKey(accessTokenHash string) string {
+	return "auth:session:" + accessTokenHash
+}
@@ -0,0 +1,99 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+// validAfterTTL bounds how long another instance may accept a token of a user
+// after all of the user's tokens were invalidated
+const validAfterTTL = time.Minute
+
+// Generate returns a random token to be sent to the user and its hash to be stored
+func Generate() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	token := hex.EncodeToString(b)
+	return token, Hash(token), nil
+}
+
+// Hash returns the SHA-256 hash of a token as stored in the database
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// InvalidatedAt returns the time before which tokens issued to the user are no
+// longer accepted, zero when they never were invalidated
+func InvalidatedAt(userID uint) (time.Time, error) {
+	load := func() (int64, error) {
+		var user models.User
+		if err := facades.Orm().Query().Select("id", "tokens_valid_after").Where("id = ?", userID).First(&user); err != nil {
+			return 0, err
+		}
+		if user.TokensValidAfter == nil {
+			return 0, nil
+		}
+		return user.TokensValidAfter.Unix(), nil
+	}
+
+	// The time is cached as JSON so that every cache store can hold it
+	value, err := facades.Cache().Remember(validAfterKey(userID), validAfterTTL, func() (any, error) {
+		unix, err := load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(unix)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var unix int64
+	if err := json.Unmarshal([]byte(fmt.Sprint(value)), &unix); err != nil {
+		facades.Cache().Forget(validAfterKey(userID))
+		if unix, err = load(); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if unix == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// Invalidated reports whether a token issued at issuedAt was invalidated at
+// invalidatedAt. The iat claim only has seconds, so a token issued within the
+// second of the invalidation is rejected too.
+func Invalidated(issuedAt, invalidatedAt time.Time) bool {
+	if invalidatedAt.IsZero() {
+		return false
+	}
+
+	return !issuedAt.After(invalidatedAt.Truncate(time.Second))
+}
+
+// Forget drops the cached invalidation time of a user after it was changed
+func Forget(userID uint) {
+	facades.Cache().Forget(validAfterKey(userID))
+}
+
+func validAfterKey(userID uint) string {
+	return "auth:tokens_valid_after:" + strconv.FormatUint(uint64(userID), 10)
+}
@@ -0,0 +1,252 @@
+package twofactor
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+)
+
+// RecoveryCodeCount is the number of recovery codes a user gets
+const RecoveryCodeCount = 10
+
+var (
+	ErrInvalidCode      = errors.New("the two-factor code is invalid")
+	ErrInvalidChallenge = errors.New("the login challenge is invalid or expired, please login again")
+)
+
+// Enable stores a new unconfirmed secret for the user and returns it
+func Enable(userID uint) (string, error) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := facades.Crypt().EncryptString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", userID).Update(map[string]any{
+		"two_factor_secret":       encrypted,
+		"two_factor_confirmed_at": nil,
+		"two_factor_last_step":    0,
+	}); err != nil {
+		return "", err
+	}
+	users.Forget(userID)
+
+	return secret, nil
+}
+
+// Confirm turns on two-factor authentication once the user proved the
+// authenticator app works, and returns the user's recovery codes
+func Confirm(user models.User, code string) ([]string, error) {
+	if err := Verify(user, code); err != nil {
+		return nil, err
+	}
+
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).
+		Update("two_factor_confirmed_at", time.Now()); err != nil {
+		return nil, err
+	}
+	users.Forget(user.ID)
+
+	return ReplaceRecoveryCodes(user.ID)
+}
+
+// Verify checks a code from the authenticator app of the user. Each code is
+// accepted once, concurrent requests with the same code are rejected too.
+func Verify(user models.User, code string) error {
+	if user.TwoFactorSecret == "" {
+		return ErrInvalidCode
+	}
+	secret, err := facades.Crypt().DecryptString(user.TwoFactorSecret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := Match(secret, code, time.Now(), user.TwoFactorLastStep)
+	if !ok {
+		return ErrInvalidCode
+	}
+
+	result, err := facades.Orm().Query().Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", user.ID, step).
+		Update("two_factor_last_step", step)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// UseRecoveryCode consumes one of the user's recovery codes
+func UseRecoveryCode(userID uint, code string) error {
+	result, err := facades.Orm().Query().Model(&models.TwoFactorRecoveryCodes{}).
+		Where("user_id = ? AND code = ? AND used_at IS NULL", userID, tokens.Hash(normalizeRecoveryCode(code))).
+		Update("used_at", time.Now())
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes discards the user's recovery codes and returns new ones,
+// only their hashes are stored
+func ReplaceRecoveryCodes(userID uint) ([]string, error) {
+	codes, err := GenerateRecoveryCodes(RecoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCodes{}); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	for _, code := range codes {
+		if err := tx.Create(&models.TwoFactorRecoveryCodes{
+			UserID: userID,
+			Code:   tokens.Hash(code),
+		}); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// Disable turns off two-factor authentication and removes the secret and recovery codes
+func Disable(userID uint) error {
+	tx, err := facades.Orm().Query().Begin()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Model(&models.User{}).Where("id = ?", userID).Update(map[string]any{
+		"two_factor_secret":       "",
+		"two_factor_confirmed_at": nil,
+		"two_factor_last_step":    0,
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorRecoveryCodes{}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	users.Forget(userID)
+
+	return nil
+}
+
+// Required reports whether the user owns a poll large enough to require
+// two-factor authentication
+func Required(userID uint) (bool, error) {
+	threshold := facades.Config().GetInt("auth.two_factor.required_poll_votes", 0)
+	if threshold <= 0 {
+		return false, nil
+	}
+
+	var exists bool
+	err := facades.Orm().Query().Model(&models.Polls{}).
+		Where("user_id = ? AND (SELECT COUNT(*) FROM votes WHERE votes.poll_id = polls.id AND votes.deleted_at IS NULL) >= ?", userID, threshold).
+		Exists(&exists)
+	return exists, err
+}
+
+// NewChallenge starts the second login step for the user and returns the
+// token that has to be presented together with a code
+func NewChallenge(userID uint) (string, error) {
+	token, hash, err := tokens.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	ttl := time.Duration(facades.Config().GetInt("auth.two_factor.challenge_ttl", 5)) * time.Minute
+	if err := facades.Cache().Put(challengeKey(hash), userID, ttl); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// CompleteChallenge checks the code or recovery code for a login challenge and
+// returns the user to sign in. A challenge is discarded once it was completed
+// or too many wrong codes were given.
+func CompleteChallenge(token, code, recoveryCode string) (uint, error) {
+	hash := tokens.Hash(token)
+
+	lock := facades.Cache().Lock(challengeKey(hash)+":lock", 10*time.Second)
+	if !lock.Get() {
+		return 0, ErrInvalidChallenge
+	}
+	defer lock.Release()
+
+	userID := uint(facades.Cache().GetInt(challengeKey(hash), 0))
+	if userID == 0 {
+		return 0, ErrInvalidChallenge
+	}
+
+	var user models.User
+	if err := facades.Orm().Query().Where("id = ?", userID).FirstOrFail(&user); err != nil {
+		return 0, ErrInvalidChallenge
+	}
+
+	var err error
+	if recoveryCode != "" {
+		err = UseRecoveryCode(user.ID, recoveryCode)
+	} else {
+		err = Verify(user, code)
+	}
+	if err != nil {
+		if errors.Is(err, ErrInvalidCode) {
+			attempts := facades.Cache().GetInt(attemptsKey(hash), 0) + 1
+			if attempts >= facades.Config().GetInt("auth.two_factor.max_attempts", 5) {
+				facades.Cache().Forget(challengeKey(hash))
+				facades.Cache().Forget(attemptsKey(hash))
+			} else {
+				ttl := time.Duration(facades.Config().GetInt("auth.two_factor.challenge_ttl", 5)) * time.Minute
+				_ = facades.Cache().Put(attemptsKey(hash), attempts, ttl)
+			}
+		}
+		return 0, err
+	}
+
+	facades.Cache().Forget(challengeKey(hash))
+	facades.Cache().Forget(attemptsKey(hash))
+	return user.ID, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+func challengeKey(hash string) string {
+	return "auth:2fa_challenge:" + hash
+}
+
+func attemptsKey(hash string) string {
+	return "auth:2fa_challenge_attempts:" + hash
+}
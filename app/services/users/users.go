@@ -0,0 +1,118 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+)
+
+var (
+	ErrNotFound   = errors.New("user not found")
+	ErrBanned     = errors.New("account is banned")
+	ErrUnverified = errors.New("email not verified")
+)
+
+// Current returns the user the auth middleware put into the context
+func Current(ctx context.Context) (models.User, bool) {
+	user, ok := ctx.Value("user").(models.User)
+	return user, ok && user.ID != 0
+}
+
+// Find returns the user with the id, cached for auth.user_cache seconds.
+// Soft-deleted users are not found.
+func Find(id uint) (models.User, error) {
+	ttl := time.Duration(facades.Config().GetInt("auth.user_cache", 60)) * time.Second
+	load := func() (models.User, error) {
+		var user models.User
+		if err := facades.Orm().Query().Where("id = ?", id).First(&user); err != nil {
+			return models.User{}, err
+		}
+		if user.ID == 0 {
+			return models.User{}, ErrNotFound
+		}
+		return user, nil
+	}
+	if ttl <= 0 {
+		return load()
+	}
+
+	// The user is cached as JSON so that every cache store can hold it
+	value, err := facades.Cache().Remember(key(id), ttl, func() (any, error) {
+		user, err := load()
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(user)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+
+	var user models.User
+	if err := json.Unmarshal([]byte(fmt.Sprint(value)), &user); err != nil || user.ID != id {
+		facades.Cache().Forget(key(id))
+		return load()
+	}
+	return user, nil
+}
+
+// Forget drops the cached user after it was changed
+func Forget(id uint) {
+	facades.Cache().Forget(key(id))
+}
+
+// Check returns why the user may not use the API, nil when they may
+func Check(user models.User) error {
+	switch {
+	case user.ID == 0 || user.DeletedAt.Valid:
+		return ErrNotFound
+	case user.BannedAt != nil:
+		return ErrBanned
+	case user.EmailVerifiedAt == nil:
+		return ErrUnverified
+	}
+	return nil
+}
+
+// Ban bans the user and revokes all of their sessions
+func Ban(user models.User, reason string) error {
+	now := time.Now()
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]any{
+		"banned_at":  &now,
+		"ban_reason": reason,
+	}); err != nil {
+		return err
+	}
+	Forget(user.ID)
+
+	return tokens.RevokeUser(user.ID)
+}
+
+// Unban lifts the ban of the user, they have to sign in again
+func Unban(user models.User) error {
+	if _, err := facades.Orm().Query().Model(&models.User{}).Where("id = ?", user.ID).Update(map[string]any{
+		"banned_at":  nil,
+		"ban_reason": "",
+	}); err != nil {
+		return err
+	}
+	Forget(user.ID)
+
+	return nil
+}
+
+func key(id uint) string {
+	return "auth:user:" + strconv.FormatUint(uint64(id), 10)
+}
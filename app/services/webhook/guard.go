@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/goravel/framework/facades"
+)
+
+var (
+	ErrInsecureURL    = errors.New("the webhook URL must use https")
+	ErrBlockedAddress = errors.New("the webhook URL must not point to a loopback, link-local, private or unspecified address")
+)
+
+// ValidateURL checks that a webhook URL uses https, or http in the local
+// environment, and that its host only resolves to public addresses
+func ValidateURL(raw string) error {
+	u, err := checkScheme(raw)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+	if err != nil {
+		return err
+	}
+	for _, addr := range addrs {
+		if Blocked(addr.IP) {
+			return ErrBlockedAddress
+		}
+	}
+
+	return nil
+}
+
+// Blocked reports whether deliveries to the address are refused, so that
+// webhooks cannot reach internal services
+func Blocked(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast()
+}
+
+// NewClient returns the HTTP client deliveries are sent with. It checks the
+// address of every connection, including redirects, as the host may resolve
+// differently than when the webhook was created.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || Blocked(ip) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("stopped after 5 redirects")
+			}
+			_, err := checkScheme(req.URL.String())
+			return err
+		},
+	}
+}
+
+func checkScheme(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, errors.New("the webhook URL has no host")
+	}
+
+	local := facades.Config().GetString("app.env") == "local"
+	if u.Scheme != "https" && !(local && u.Scheme == "http") {
+		return nil, ErrInsecureURL
+	}
+
+	return u, nil
+}
@@ -0,0 +1,220 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/goravel/framework/facades"
+
+	"evote-be/app/models"
+)
+
+const (
+	SignatureHeader = "X-Evote-Signature"
+	EventHeader     = "X-Evote-Event"
+	DeliveryHeader  = "X-Evote-Delivery"
+)
+
+// maxResponseBody limits how much of a subscriber response is kept in the delivery log
+const maxResponseBody = 2048
+
+// Payload is the JSON body posted to subscribers
+type Payload struct {
+	ID        uint                `json:"id"`
+	Event     models.WebhookEvent `json:"event"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      any                 `json:"data"`
+}
+
+// VoteData is the payload data of a vote.cast event. Voters are not disclosed.
+type VoteData struct {
+	PollID     uint `json:"poll_id"`
+	OptionID   uint `json:"option_id"`
+	VoteID     uint `json:"vote_id"`
+	VotesCount uint `json:"votes_count"`
+}
+
+// GenerateSecret returns a random secret used to sign deliveries
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
+
+// Sign computes the signature header value for a body sent at the given time.
+// Subscribers verify it by computing HMAC-SHA256 over "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Record stores a pending delivery for every webhook registered on the poll,
+// by its owner or a collaborator, and every account-wide webhook of the poll
+// owner that is subscribed to the event, and returns the delivery IDs to be
+// queued.
+func Record(poll models.Polls, event models.WebhookEvent, data any) ([]uint, error) {
+	var webhooks []models.Webhooks
+	if err := facades.Orm().Query().
+		Where("active = ?", true).
+		Where("poll_id = ? OR (poll_id IS NULL AND user_id = ?)", poll.ID, poll.UserID).
+		Find(&webhooks); err != nil {
+		return nil, err
+	}
+
+	var ids []uint
+	for _, hook := range webhooks {
+		if !hook.Subscribes(event) {
+			continue
+		}
+
+		delivery := models.WebhookDeliveries{
+			WebhookID: hook.ID,
+			Event:     event,
+			Status:    models.DeliveryPending,
+		}
+		if err := facades.Orm().Query().Create(&delivery); err != nil {
+			return nil, err
+		}
+
+		payload, err := json.Marshal(Payload{
+			ID:        delivery.ID,
+			Event:     event,
+			CreatedAt: delivery.CreatedAt.StdTime(),
+			Data:      data,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.Payload = string(payload)
+		if err := facades.Orm().Query().Save(&delivery); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, delivery.ID)
+	}
+
+	return ids, nil
+}
+
+// Redeliver copies a previous delivery into a new pending one, keeping the
+// original payload, and returns the new delivery ID.
+func Redeliver(original models.WebhookDeliveries) (uint, error) {
+	delivery := models.WebhookDeliveries{
+		WebhookID: original.WebhookID,
+		Event:     original.Event,
+		Payload:   original.Payload,
+		Status:    models.DeliveryPending,
+	}
+	if err := facades.Orm().Query().Create(&delivery); err != nil {
+		return 0, err
+	}
+
+	return delivery.ID, nil
+}
+
+// Deliver performs a single delivery attempt. When the attempt failed and the
+// delivery may be retried, the time of the next attempt is returned.
+func Deliver(deliveryID uint) (*time.Time, error) {
+	var delivery models.WebhookDeliveries
+	if err := facades.Orm().Query().With("Webhook").Where("id = ?", deliveryID).FirstOrFail(&delivery); err != nil {
+		return nil, err
+	}
+	if delivery.Status != models.DeliveryPending {
+		return nil, nil
+	}
+
+	// The webhook was removed after the delivery was queued
+	if delivery.Webhook.ID == 0 || !delivery.Webhook.Active {
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = "webhook is no longer active"
+		return nil, facades.Orm().Query().Save(&delivery)
+	}
+
+	delivery.Attempts++
+	code, body, err := post(delivery)
+	delivery.ResponseCode = code
+	delivery.ResponseBody = body
+	delivery.NextAttemptAt = nil
+
+	if err == nil {
+		now := time.Now()
+		delivery.Status = models.DeliverySucceeded
+		delivery.DeliveredAt = &now
+		delivery.Error = ""
+		return nil, facades.Orm().Query().Save(&delivery)
+	}
+
+	delivery.Error = err.Error()
+	if delivery.Attempts >= uint(facades.Config().GetInt("webhook.max_attempts", 6)) {
+		delivery.Status = models.DeliveryFailed
+		return nil, facades.Orm().Query().Save(&delivery)
+	}
+
+	next := time.Now().Add(Backoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+	if err := facades.Orm().Query().Save(&delivery); err != nil {
+		return nil, err
+	}
+
+	return &next, nil
+}
+
+// Backoff returns the delay before the next attempt after the given number of attempts
+func Backoff(attempts uint) time.Duration {
+	base := facades.Config().GetInt("webhook.backoff", 30)
+	ceiling := facades.Config().GetInt("webhook.max_backoff", 3600)
+
+	delay := float64(base) * math.Pow(2, float64(attempts-1))
+	if delay > float64(ceiling) {
+		delay = float64(ceiling)
+	}
+	return time.Duration(delay) * time.Second
+}
+
+func post(delivery models.WebhookDeliveries) (int, string, error) {
+	body := []byte(delivery.Payload)
+
+	if _, err := checkScheme(delivery.Webhook.URL); err != nil {
+		return 0, "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Evote-Webhook/1.0")
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Webhook.Secret, time.Now().Unix(), body))
+
+	client := NewClient(time.Duration(facades.Config().GetInt("webhook.timeout", 10)) * time.Second)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, string(respBody), fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, string(respBody), nil
+}
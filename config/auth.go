@@ -32,5 +32,98 @@ func init() {
 				"driver": "jwt",
 			},
 		},
+
+		// Default Role
+		//
+		// Users without a role get the permissions of this role. The roles are
+		// created by the RoleSeeder: admin, organizer, voter and auditor. Set
+		// it to organizer to let every account create polls without being
+		// assigned a role.
+		"default_role": config.Env("AUTH_DEFAULT_ROLE", "voter"),
+
+		// Authenticated User Cache
+		//
+		// The auth middleware loads the user of every request. The user is
+		// cached for this number of seconds, a banned or deleted account is
+		// rejected by other instances at the latest when it expires.
+		"user_cache": config.Env("AUTH_USER_CACHE", 60),
+
+		// Email Verification
+		//
+		// The expire time is the number of minutes that each verification link
+		// will be considered valid. The cooldown is the number of seconds a user
+		// has to wait before another verification email can be sent.
+		"verification": map[string]any{
+			"expire":   config.Env("VERIFICATION_EXPIRE", 1440),
+			"cooldown": config.Env("VERIFICATION_COOLDOWN", 60),
+		},
+
+		// Two-Factor Authentication
+		//
+		// The issuer is shown in authenticator apps. After the password is
+		// checked, the challenge token of the login is valid for challenge_ttl
+		// minutes and max_attempts codes. Owners of a poll with at least
+		// required_poll_votes votes must have two-factor authentication on to
+		// manage their polls, 0 disables the requirement.
+		"two_factor": map[string]any{
+			"issuer":              config.Env("TWO_FACTOR_ISSUER", "Evote"),
+			"challenge_ttl":       config.Env("TWO_FACTOR_CHALLENGE_TTL", 5),
+			"max_attempts":        config.Env("TWO_FACTOR_MAX_ATTEMPTS", 5),
+			"required_poll_votes": config.Env("TWO_FACTOR_REQUIRED_POLL_VOTES", 100),
+		},
+
+		// Passwordless Login
+		//
+		// Users may login with a link or code sent to their email instead of the
+		// password. Both are valid for expire minutes, the code for max_attempts
+		// tries. The url is the page of the frontend that posts the token from
+		// the link. Login emails are limited to per_email an hour for an address
+		// and per_ip an hour for a client.
+		"passwordless": map[string]any{
+			"enabled":      config.Env("PASSWORDLESS_ENABLED", true),
+			"expire":       config.Env("PASSWORDLESS_EXPIRE", 10),
+			"url":          config.Env("PASSWORDLESS_URL", "http://localhost:3000/auth/login/link"),
+			"max_attempts": config.Env("PASSWORDLESS_MAX_ATTEMPTS", 5),
+			"per_email":    config.Env("PASSWORDLESS_PER_EMAIL", 5),
+			"per_ip":       config.Env("PASSWORDLESS_PER_IP", 20),
+		},
+
+		// Login Lockout
+		//
+		// After a failed password login the email is refused for a delay that
+		// doubles with every failure, starting at delay and capped at max_delay
+		// milliseconds, clients are told when to retry with Retry-After.
+		// After max_attempts failures for an email within window minutes, or
+		// max_attempts_per_ip failures from a client, logins are refused for
+		// duration minutes. The owner of a locked account gets an email.
+		"lockout": map[string]any{
+			"max_attempts":        config.Env("LOCKOUT_MAX_ATTEMPTS", 5),
+			"max_attempts_per_ip": config.Env("LOCKOUT_MAX_ATTEMPTS_PER_IP", 20),
+			"window":              config.Env("LOCKOUT_WINDOW", 15),
+			"duration":            config.Env("LOCKOUT_DURATION", 15),
+			"delay":               config.Env("LOCKOUT_DELAY", 250),
+			"max_delay":           config.Env("LOCKOUT_MAX_DELAY", 4000),
+		},
+
+		// API Keys
+		//
+		// Users create scoped API keys for scripts and third-party integrations.
+		// A key expires after expire days unless another expiry is picked, at
+		// most max_expire days. A user can have up to max_keys active keys.
+		"api_keys": map[string]any{
+			"expire":     config.Env("API_KEY_EXPIRE", 90),
+			"max_expire": config.Env("API_KEY_MAX_EXPIRE", 365),
+			"max_keys":   config.Env("API_KEY_MAX_KEYS", 20),
+		},
+
+		// Resetting Passwords
+		//
+		// The expire time is the number of minutes that each reset token will be
+		// considered valid. The url is the page of the frontend where the token
+		// from the email is entered together with the new password.
+		"passwords": map[string]any{
+			"expire": config.Env("PASSWORD_RESET_EXPIRE", 60),
+			"url":    config.Env("PASSWORD_RESET_URL", "http://localhost:3000/auth/password/reset"),
+		},
 	})
 }
@@ -1,11 +1,36 @@
 package config
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/goravel/framework/facades"
 )
 
 func init() {
 	config := facades.Config()
+
+	// Allowed Origins
+	//
+	// Comma separated list of origins the frontend is served from, the only
+	// ones allowed to call the API from a browser. Local environments allow
+	// the development frontend when none are set, other environments allow
+	// none: no CORS headers are sent and browsers block cross-origin calls.
+	defaultOrigins := ""
+	if config.GetString("app.env") == "local" {
+		defaultOrigins = "http://localhost:3000"
+	}
+	origins := []string{}
+	for _, origin := range strings.Split(fmt.Sprint(config.Env("CORS_ALLOWED_ORIGINS", defaultOrigins)), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	paths := []string{"*"}
+	if len(origins) == 0 {
+		paths = []string{}
+	}
+
 	config.Add("cors", map[string]any{
 		// Cross-Origin Resource Sharing (CORS) Configuration
 		//
@@ -14,12 +39,15 @@ func init() {
 		// in web browsers. You are free to adjust these settings as needed.
 		//
 		// To learn more: https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS
-		"paths":                []string{"*"},
-		"allowed_methods":      []string{"*"},
-		"allowed_origins":      []string{"*"},
-		"allowed_headers":      []string{"*"},
-		"exposed_headers":      []string{""},
-		"max_age":              0,
+		"paths":           paths,
+		"allowed_methods": []string{"GET", "POST", "PUT", "DELETE"},
+		"allowed_origins": origins,
+		"allowed_headers": []string{
+			"Accept", "Authorization", "Content-Type", "X-Requested-With", "X-CSRF-Token",
+			"X-Organization", "X-Device-Fingerprint", "X-Pow-Challenge", "X-Pow-Nonce",
+		},
+		"exposed_headers":      []string{"Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"},
+		"max_age":              config.Env("CORS_MAX_AGE", 600),
 		"supports_credentials": false,
 	})
 }
@@ -9,11 +9,21 @@ func init() {
 	config.Add("jwt", map[string]any{
 		// JWT Authentication Secret
 		//
-		// Don't forget to set this in your .env file, as it will be used to sign
-		// your tokens. A helper command is provided for this:
-		// `go run . artisan jwt:secret`
+		// Tokens were signed with this secret before signing keys were used, it
+		// is kept to accept them until they expire. A helper command is provided
+		// for this: `go run . artisan jwt:secret`
 		"secret": config.Env("JWT_SECRET", ""),
 
+		// Signing Keys
+		//
+		// Access tokens are signed with a key pair of the "algorithm", RS256 or
+		// EdDSA, and carry the id of the key in the "kid" header. The public keys
+		// are published at /.well-known/jwks.json for other services to verify
+		// tokens issued by "issuer". Rotate keys with `go run . artisan jwt:rotate`,
+		// replaced keys are published until the tokens they signed expired.
+		"algorithm": config.Env("JWT_ALGORITHM", "RS256"),
+		"issuer":    config.Env("JWT_ISSUER", "evote"),
+
 		// JWT time to live
 		//
 		// Specify the length of time (in minutes) that the token will be valid for.
@@ -27,10 +37,10 @@ func init() {
 
 		// Refresh time to live
 		//
-		// Specify the length of time (in minutes) that the token can be refreshed
-		// within. I.E. The user can refresh their token within a 2 week window of
-		// the original token being created until they must re-authenticate.
-		// Defaults to 2 weeks.
+		// Specify the length of time (in minutes) that a refresh token issued with
+		// an access token can be exchanged at /auth/refresh. Every exchange rotates
+		// the refresh token, so a session stays alive as long as it is used within
+		// this window. Defaults to 2 weeks.
 		//
 		// You can also set this to 0, to yield an infinite refresh time.
 		// Some may want this instead of never expiring tokens for e.g. a mobile app.
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("oidc", map[string]any{
+		// Redirect URL
+		//
+		// The page of the frontend the providers send the user back to with the
+		// code and state, which are then posted to /auth/oidc/{provider}/callback.
+		// {provider} is replaced with the name of the provider.
+		"redirect_url": config.Env("OIDC_REDIRECT_URL", "http://localhost:3000/auth/callback/{provider}"),
+
+		// State Lifetime
+		//
+		// The number of minutes the user has to complete the login at the provider.
+		"state_ttl": config.Env("OIDC_STATE_TTL", 10),
+
+		// Login Providers
+		//
+		// Providers without a client id are disabled. OpenID Connect providers
+		// only need the issuer, their endpoints are discovered. Plain OAuth 2
+		// providers set the auth, token and userinfo urls instead; the emails url
+		// tells which email addresses of the user are verified.
+		"providers": map[string]any{
+			"google": map[string]any{
+				"issuer":        config.Env("GOOGLE_OIDC_ISSUER", "https://accounts.google.com"),
+				"client_id":     config.Env("GOOGLE_CLIENT_ID", ""),
+				"client_secret": config.Env("GOOGLE_CLIENT_SECRET", ""),
+				"scopes":        "openid email profile",
+			},
+			"github": map[string]any{
+				"client_id":     config.Env("GITHUB_CLIENT_ID", ""),
+				"client_secret": config.Env("GITHUB_CLIENT_SECRET", ""),
+				"scopes":        "read:user user:email",
+				"auth_url":      "https://github.com/login/oauth/authorize",
+				"token_url":     "https://github.com/login/oauth/access_token",
+				"userinfo_url":  "https://api.github.com/user",
+				"emails_url":    "https://api.github.com/user/emails",
+			},
+			"corporate": map[string]any{
+				"issuer":        config.Env("CORPORATE_OIDC_ISSUER", ""),
+				"client_id":     config.Env("CORPORATE_OIDC_CLIENT_ID", ""),
+				"client_secret": config.Env("CORPORATE_OIDC_CLIENT_SECRET", ""),
+				"scopes":        config.Env("CORPORATE_OIDC_SCOPES", "openid email profile"),
+			},
+		},
+	})
+}
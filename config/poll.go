@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("poll", map[string]any{
+		// Lifecycle Scheduler
+		//
+		// Polls are started and ended by timers set at their exact start and end
+		// date. Timers are only kept for transitions due within the "horizon"
+		// (in minutes) and are rebuilt from the database every "resync" minutes,
+		// which also picks up polls created by other instances.
+		"scheduler": map[string]any{
+			"horizon": config.Env("POLL_SCHEDULER_HORIZON", 60),
+			"resync":  config.Env("POLL_SCHEDULER_RESYNC", 5),
+		},
+
+		// Collaborator Invitations
+		//
+		// Invitations to collaborate on a poll are emailed with a link to the
+		// "url" of the frontend and can be accepted until they "expire" (in
+		// hours) by the user with the invited email.
+		"invitations": map[string]any{
+			"expire": config.Env("POLL_INVITATION_EXPIRE", 72),
+			"url":    config.Env("POLL_INVITATION_URL", "http://localhost:3000/polls/invitations/accept"),
+		},
+
+		// Ballot Stuffing Detection
+		//
+		// Every vote is scored against the heuristics below, each one that
+		// matches adds its weight. Votes scoring at least the threshold are
+		// flagged for the owner of the poll to review. A vote matches when
+		// ip_votes votes of the poll came from its IP within ip_window minutes,
+		// when fingerprint_votes votes of the poll share its device fingerprint,
+		// when the account is younger than new_account hours, or when it was
+		// sent without a user agent. A weight of 0 turns a heuristic off.
+		"ballot_stuffing": map[string]any{
+			"threshold":                 config.Env("BALLOT_STUFFING_THRESHOLD", 50),
+			"ip_window":                 config.Env("BALLOT_STUFFING_IP_WINDOW", 10),
+			"ip_votes":                  config.Env("BALLOT_STUFFING_IP_VOTES", 3),
+			"ip_weight":                 config.Env("BALLOT_STUFFING_IP_WEIGHT", 40),
+			"fingerprint_votes":         config.Env("BALLOT_STUFFING_FINGERPRINT_VOTES", 1),
+			"fingerprint_weight":        config.Env("BALLOT_STUFFING_FINGERPRINT_WEIGHT", 50),
+			"new_account":               config.Env("BALLOT_STUFFING_NEW_ACCOUNT", 24),
+			"new_account_weight":        config.Env("BALLOT_STUFFING_NEW_ACCOUNT_WEIGHT", 30),
+			"missing_user_agent_weight": config.Env("BALLOT_STUFFING_MISSING_USER_AGENT_WEIGHT", 20),
+		},
+	})
+}
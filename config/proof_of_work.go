@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("proof_of_work", map[string]any{
+		// Proof of Work
+		//
+		// Guarded endpoints only answer clients that solved a challenge from
+		// /pow/challenge: a nonce for which the SHA-256 hash of
+		// "challenge:nonce" starts with "difficulty" zero bits. Turned off by
+		// default, the guard then lets every request through.
+		"enabled": config.Env("POW_ENABLED", false),
+
+		// Difficulty
+		//
+		// Clients start at "difficulty" bits. Every "step" requests a client sent
+		// within the last minute add a bit, up to "max_difficulty". Each extra bit
+		// doubles the work to solve a challenge.
+		"difficulty":     config.Env("POW_DIFFICULTY", 16),
+		"max_difficulty": config.Env("POW_MAX_DIFFICULTY", 24),
+		"step":           config.Env("POW_STEP", 30),
+
+		// A challenge has to be solved within "expire" seconds and can only be
+		// used once, by the client it was issued to.
+		"expire": config.Env("POW_EXPIRE", 120),
+	})
+}
@@ -0,0 +1,45 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("rate_limit", map[string]any{
+		// Rate Limiting
+		//
+		// Turns every named rate limiter off when false, e.g. for load tests.
+		// Counters are kept in the default cache store.
+		"enabled": config.Env("RATE_LIMIT_ENABLED", true),
+
+		// Named Rate Limiters
+		//
+		// Each limiter allows "attempts" requests per "decay" minutes for a key,
+		// a limiter with no attempts is off. Throttled requests get a 429 with a
+		// Retry-After header.
+		//
+		// auth:         per client IP, shared by the /auth endpoints
+		// votes:        per user, for casting votes
+		// public_polls: per poll code, for /polls/public
+		// pow:          per client IP, for proof-of-work challenges
+		"limiters": map[string]any{
+			"auth": map[string]any{
+				"attempts": config.Env("RATE_LIMIT_AUTH_ATTEMPTS", 20),
+				"decay":    config.Env("RATE_LIMIT_AUTH_DECAY", 1),
+			},
+			"votes": map[string]any{
+				"attempts": config.Env("RATE_LIMIT_VOTES_ATTEMPTS", 10),
+				"decay":    config.Env("RATE_LIMIT_VOTES_DECAY", 1),
+			},
+			"public_polls": map[string]any{
+				"attempts": config.Env("RATE_LIMIT_PUBLIC_POLLS_ATTEMPTS", 120),
+				"decay":    config.Env("RATE_LIMIT_PUBLIC_POLLS_DECAY", 1),
+			},
+			"pow": map[string]any{
+				"attempts": config.Env("RATE_LIMIT_POW_ATTEMPTS", 30),
+				"decay":    config.Env("RATE_LIMIT_POW_DECAY", 1),
+			},
+		},
+	})
+}
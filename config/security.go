@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	production := config.GetString("app.env") == "production"
+
+	// HSTS pins the domain to HTTPS, outside of production only when configured
+	hstsMaxAge := 0
+	if production {
+		hstsMaxAge = 31536000
+	}
+
+	config.Add("security", map[string]any{
+		// Security Headers
+		//
+		// Sent with every response. HSTS tells browsers to only use HTTPS for
+		// "max_age" seconds, 0 turns it off. The content security policy allows
+		// the assets of the server-rendered views and the Swagger UI. Set a
+		// header to an empty value to leave it out.
+		"headers": map[string]any{
+			"hsts": map[string]any{
+				"max_age":            config.Env("SECURITY_HSTS_MAX_AGE", hstsMaxAge),
+				"include_subdomains": config.Env("SECURITY_HSTS_INCLUDE_SUBDOMAINS", true),
+			},
+			"content_security_policy": config.Env("SECURITY_CONTENT_SECURITY_POLICY", "default-src 'self'; "+
+				"script-src 'self' 'unsafe-inline' https://cdn.tailwindcss.com; "+
+				"style-src 'self' 'unsafe-inline' https://fonts.bunny.net; "+
+				"font-src 'self' https://fonts.bunny.net; "+
+				"img-src 'self' data: https://www.goravel.dev; "+
+				"frame-ancestors 'none'; base-uri 'self'; form-action 'self'"),
+			"frame_options":   config.Env("SECURITY_FRAME_OPTIONS", "DENY"),
+			"referrer_policy": config.Env("SECURITY_REFERRER_POLICY", "strict-origin-when-cross-origin"),
+		},
+
+		// CSRF Protection
+		//
+		// Forms of the server-rendered views post the token of the "cookie" back
+		// in the "field" input or the "header". The API authenticates with
+		// bearer tokens and doesn't need it. Secure cookies are only sent over
+		// HTTPS, which production is expected to use.
+		"csrf": map[string]any{
+			"cookie": config.Env("CSRF_COOKIE", "XSRF-TOKEN"),
+			"field":  "_token",
+			"header": "X-CSRF-Token",
+			"secure": config.Env("CSRF_COOKIE_SECURE", production),
+		},
+	})
+}
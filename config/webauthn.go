@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("webauthn", map[string]any{
+		// Relying Party
+		//
+		// The id is the domain passkeys are bound to, it must be the domain of
+		// the frontend or a parent of it. The name is shown by authenticators.
+		"rp_id":   config.Env("WEBAUTHN_RP_ID", "localhost"),
+		"rp_name": config.Env("WEBAUTHN_RP_NAME", "Evote"),
+
+		// Allowed Origins
+		//
+		// Comma separated list of origins the frontend is served from. Responses
+		// of authenticators created on any other origin are rejected.
+		"origins": config.Env("WEBAUTHN_ORIGINS", "http://localhost:3000"),
+
+		// Ceremony Timeout
+		//
+		// The number of seconds a registration or login challenge is valid for.
+		"timeout": config.Env("WEBAUTHN_TIMEOUT", 300),
+	})
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"github.com/goravel/framework/facades"
+)
+
+func init() {
+	config := facades.Config()
+	config.Add("webhook", map[string]any{
+		// Webhook Request Timeout
+		//
+		// The number of seconds to wait for a subscriber to answer a delivery
+		// before the attempt is considered failed.
+		"timeout": config.Env("WEBHOOK_TIMEOUT", 10),
+
+		// Maximum Delivery Attempts
+		//
+		// How many times a delivery is attempted before it is marked as failed.
+		// Failed deliveries can still be redelivered manually.
+		"max_attempts": config.Env("WEBHOOK_MAX_ATTEMPTS", 6),
+
+		// Retry Backoff
+		//
+		// The base delay (in seconds) between attempts. The delay doubles after
+		// every failed attempt and is capped by "max_backoff".
+		"backoff":     config.Env("WEBHOOK_BACKOFF", 30),
+		"max_backoff": config.Env("WEBHOOK_MAX_BACKOFF", 3600),
+	})
+}
@@ -17,6 +17,27 @@ func (kernel Kernel) Migrations() []schema.Migration {
 		&migrations.M20250308113928CreatePollsTable{},
 		&migrations.M20250308204957CreateOptionsTable{},
 		&migrations.M20250308204808CreateVotesTable{},
+		&migrations.M20250410093012CreateWebhooksTable{},
+		&migrations.M20250415141207CreateAnalyticsEventsTable{},
+		&migrations.M20250422103540CreatePollTransitionsTable{},
+		&migrations.M20250429091530CreatePollAmendmentsTable{},
+		&migrations.M20250506102214CreatePasswordResetsTable{},
+		&migrations.M20250513084107ConvertUserVerificationColumns{},
+		&migrations.M20250520113045CreateRefreshTokensTable{},
+		&migrations.M20250527090312CreateUserSessionsTable{},
+		&migrations.M20250603141722AddTwoFactorToUsersTable{},
+		&migrations.M20250610093455CreatePasskeyCredentialsTable{},
+		&migrations.M20250617101530CreateUserIdentitiesTable{},
+		&migrations.M20250624083218CreateLoginTokensTable{},
+		&migrations.M20250701092047CreateRolesTables{},
+		&migrations.M20250708101245CreatePollCollaboratorsTables{},
+		&migrations.M20250715094530CreateOrganizationsTables{},
+		&migrations.M20250722083012CreatePollEligibilityRulesTable{},
+		&migrations.M20250729091518CreateLoginAttemptsTable{},
+		&migrations.M20250805102733AddRiskSignalsToVotesTable{},
+		&migrations.M20250812093104CreateSigningKeysTable{},
+		&migrations.M20250819141207AddBannedAtToUsersTable{},
+		&migrations.M20250826103415CreateApiKeysTable{},
 	}
 }
 
@@ -26,5 +47,6 @@ func (kernel Kernel) Seeders() []seeder.Seeder {
 		&seeders.UserSeeder{},
 		&seeders.PollSeeder{},
 		&seeders.OptionSeeder{},
+		&seeders.RoleSeeder{},
 	}
 }
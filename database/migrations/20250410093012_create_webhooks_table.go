@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250410093012CreateWebhooksTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250410093012CreateWebhooksTable) Signature() string {
+	return "20250410093012_create_webhooks_table"
+}
+
+// Up Run the migrations.
+func (r *M20250410093012CreateWebhooksTable) Up() error {
+	if !facades.Schema().HasTable("webhooks") {
+		if err := facades.Schema().Create("webhooks", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.UnsignedBigInteger("poll_id").Nullable()
+			table.String("url")
+			table.String("secret")
+			table.String("events")
+			table.Boolean("active").Default(true)
+			table.Timestamps()
+			table.SoftDeletes()
+
+			table.Foreign("user_id").References("id").On("users")
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Index("user_id")
+			table.Index("poll_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("webhook_deliveries") {
+		return facades.Schema().Create("webhook_deliveries", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("webhook_id")
+			table.String("event")
+			table.Text("payload")
+			table.String("status")
+			table.UnsignedInteger("attempts").Default(0)
+			table.Integer("response_code").Default(0)
+			table.Text("response_body").Nullable()
+			table.Text("error").Nullable()
+			table.Timestamp("next_attempt_at").Nullable()
+			table.Timestamp("delivered_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("webhook_id").References("id").On("webhooks").CascadeOnDelete()
+			table.Index("webhook_id")
+			table.Index("status")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250410093012CreateWebhooksTable) Down() error {
+	if err := facades.Schema().DropIfExists("webhook_deliveries"); err != nil {
+		return err
+	}
+	return facades.Schema().DropIfExists("webhooks")
+}
@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250415141207CreateAnalyticsEventsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250415141207CreateAnalyticsEventsTable) Signature() string {
+	return "20250415141207_create_analytics_events_table"
+}
+
+// Up Run the migrations.
+func (r *M20250415141207CreateAnalyticsEventsTable) Up() error {
+	if !facades.Schema().HasTable("analytics_events") {
+		return facades.Schema().Create("analytics_events", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("event")
+			table.UnsignedBigInteger("poll_id")
+			table.UnsignedBigInteger("option_id").Nullable()
+			table.Timestamps()
+
+			table.Index("poll_id", "event")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250415141207CreateAnalyticsEventsTable) Down() error {
+	return facades.Schema().DropIfExists("analytics_events")
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250422103540CreatePollTransitionsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250422103540CreatePollTransitionsTable) Signature() string {
+	return "20250422103540_create_poll_transitions_table"
+}
+
+// Up Run the migrations.
+func (r *M20250422103540CreatePollTransitionsTable) Up() error {
+	if !facades.Schema().HasTable("poll_transitions") {
+		return facades.Schema().Create("poll_transitions", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("poll_id")
+			table.String("from_status")
+			table.String("to_status")
+			table.String("reason")
+			table.UnsignedBigInteger("user_id").Nullable()
+			table.Timestamps()
+
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Foreign("user_id").References("id").On("users")
+			table.Index("poll_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250422103540CreatePollTransitionsTable) Down() error {
+	return facades.Schema().DropIfExists("poll_transitions")
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250429091530CreatePollAmendmentsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250429091530CreatePollAmendmentsTable) Signature() string {
+	return "20250429091530_create_poll_amendments_table"
+}
+
+// Up Run the migrations.
+func (r *M20250429091530CreatePollAmendmentsTable) Up() error {
+	if !facades.Schema().HasTable("poll_amendments") {
+		return facades.Schema().Create("poll_amendments", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("poll_id")
+			table.String("field")
+			table.Text("old_value")
+			table.Text("new_value")
+			table.String("reason")
+			table.UnsignedBigInteger("user_id")
+			table.Timestamps()
+
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Foreign("user_id").References("id").On("users")
+			table.Index("poll_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250429091530CreatePollAmendmentsTable) Down() error {
+	return facades.Schema().DropIfExists("poll_amendments")
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250506102214CreatePasswordResetsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250506102214CreatePasswordResetsTable) Signature() string {
+	return "20250506102214_create_password_resets_table"
+}
+
+// Up Run the migrations.
+func (r *M20250506102214CreatePasswordResetsTable) Up() error {
+	if !facades.Schema().HasTable("password_resets") {
+		if err := facades.Schema().Create("password_resets", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("token")
+			table.Timestamp("expires_at")
+			table.Timestamp("used_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("token")
+			table.Index("user_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasColumn("users", "tokens_valid_after") {
+		return facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.Timestamp("tokens_valid_after").Nullable()
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250506102214CreatePasswordResetsTable) Down() error {
+	if facades.Schema().HasColumn("users", "tokens_valid_after") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.DropColumn("tokens_valid_after")
+		}); err != nil {
+			return err
+		}
+	}
+
+	return facades.Schema().DropIfExists("password_resets")
+}
@@ -0,0 +1,188 @@
+package migrations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+// legacyDateLayout is the format the verification dates were stored in as strings
+const legacyDateLayout = "2006-01-02 15:04"
+
+type M20250513084107ConvertUserVerificationColumns struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250513084107ConvertUserVerificationColumns) Signature() string {
+	return "20250513084107_convert_user_verification_columns"
+}
+
+// Up Run the migrations.
+func (r *M20250513084107ConvertUserVerificationColumns) Up() error {
+	if facades.Schema().HasColumn("users", "verification_expires_at") {
+		return nil
+	}
+
+	// Parse the string dates and hash the pending tokens before changing
+	// anything, a date that cannot be parsed fails the migration instead of
+	// e.g. un-verifying the user
+	var rows []struct {
+		ID                uint
+		EmailVerifiedAt   *string
+		TokenExpiresAt    *string
+		VerificationToken *string
+	}
+	if err := facades.Orm().Query().
+		Raw("SELECT id, email_verified_at, token_expires_at, verification_token FROM users").
+		Scan(&rows); err != nil {
+		return err
+	}
+	type conversion struct {
+		id                    uint
+		verifiedAt, expiresAt *time.Time
+		token                 string
+	}
+	conversions := make([]conversion, 0, len(rows))
+	for _, row := range rows {
+		verifiedAt, err := parseLegacyDate(row.EmailVerifiedAt)
+		if err != nil {
+			return fmt.Errorf("email_verified_at of user %d: %w", row.ID, err)
+		}
+		expiresAt, err := parseLegacyDate(row.TokenExpiresAt)
+		if err != nil {
+			return fmt.Errorf("token_expires_at of user %d: %w", row.ID, err)
+		}
+
+		// Links already sent keep working until they expire
+		token := ""
+		if row.VerificationToken != nil && *row.VerificationToken != "" {
+			sum := sha256.Sum256([]byte(*row.VerificationToken))
+			token = hex.EncodeToString(sum[:])
+		}
+		conversions = append(conversions, conversion{id: row.ID, verifiedAt: verifiedAt, expiresAt: expiresAt, token: token})
+	}
+
+	return transaction(func(query orm.Query) error {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.Timestamp("verified_at_tmp").Nullable()
+			table.Timestamp("verification_expires_at").Nullable()
+			table.Timestamp("verification_sent_at").Nullable()
+		}); err != nil {
+			return err
+		}
+
+		for _, c := range conversions {
+			if _, err := query.Exec(
+				"UPDATE users SET verified_at_tmp = ?, verification_expires_at = ?, verification_token = ? WHERE id = ?",
+				c.verifiedAt, c.expiresAt, c.token, c.id,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := facades.Schema().DropColumns("users", []string{"email_verified_at", "token_expires_at"}); err != nil {
+			return err
+		}
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.Timestamp("email_verified_at").Nullable()
+		}); err != nil {
+			return err
+		}
+		if _, err := query.Exec("UPDATE users SET email_verified_at = verified_at_tmp"); err != nil {
+			return err
+		}
+
+		return facades.Schema().DropColumns("users", []string{"verified_at_tmp"})
+	})
+}
+
+// Down Reverse the migrations. Pending tokens stay hashed, so verification
+// links sent before cannot be used anymore.
+func (r *M20250513084107ConvertUserVerificationColumns) Down() error {
+	if !facades.Schema().HasColumn("users", "verification_expires_at") {
+		return nil
+	}
+
+	return transaction(func(query orm.Query) error {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.String("verified_at_tmp").Nullable()
+			table.String("token_expires_at").Nullable()
+		}); err != nil {
+			return err
+		}
+
+		var rows []struct {
+			ID                    uint
+			EmailVerifiedAt       *time.Time
+			VerificationExpiresAt *time.Time
+		}
+		if err := query.Raw("SELECT id, email_verified_at, verification_expires_at FROM users").Scan(&rows); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if _, err := query.Exec(
+				"UPDATE users SET verified_at_tmp = ?, token_expires_at = ? WHERE id = ?",
+				formatLegacyDate(row.EmailVerifiedAt), formatLegacyDate(row.VerificationExpiresAt), row.ID,
+			); err != nil {
+				return err
+			}
+		}
+
+		if err := facades.Schema().DropColumns("users", []string{"email_verified_at", "verification_expires_at", "verification_sent_at"}); err != nil {
+			return err
+		}
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.String("email_verified_at").Nullable()
+		}); err != nil {
+			return err
+		}
+		if _, err := query.Exec("UPDATE users SET email_verified_at = verified_at_tmp"); err != nil {
+			return err
+		}
+
+		return facades.Schema().DropColumns("users", []string{"verified_at_tmp"})
+	})
+}
+
+// transaction runs the conversion in the transaction migrate runs Up in, or
+// in a new one. The schema changes join it, so on databases with
+// transactional DDL a failure leaves the table as it was.
+func transaction(fn func(query orm.Query) error) error {
+	if query := facades.Orm().Query(); query.InTransaction() {
+		return fn(query)
+	}
+
+	return facades.Orm().Transaction(func(tx orm.Query) error {
+		query := facades.Orm().Query()
+		facades.Orm().SetQuery(tx)
+		defer facades.Orm().SetQuery(query)
+
+		return fn(tx)
+	})
+}
+
+func parseLegacyDate(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
+	}
+
+	t, err := time.ParseInLocation(legacyDateLayout, *value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func formatLegacyDate(value *time.Time) *string {
+	if value == nil {
+		return nil
+	}
+
+	s := value.In(time.Local).Format(legacyDateLayout)
+	return &s
+}
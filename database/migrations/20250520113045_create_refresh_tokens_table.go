@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250520113045CreateRefreshTokensTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250520113045CreateRefreshTokensTable) Signature() string {
+	return "20250520113045_create_refresh_tokens_table"
+}
+
+// Up Run the migrations.
+func (r *M20250520113045CreateRefreshTokensTable) Up() error {
+	if !facades.Schema().HasTable("refresh_tokens") {
+		return facades.Schema().Create("refresh_tokens", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("family_id")
+			table.String("token")
+			table.String("access_token")
+			table.Timestamp("access_expires_at")
+			table.Timestamp("expires_at")
+			table.Timestamp("used_at").Nullable()
+			table.Timestamp("revoked_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("token")
+			table.Index("access_token")
+			table.Index("family_id")
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250520113045CreateRefreshTokensTable) Down() error {
+	return facades.Schema().DropIfExists("refresh_tokens")
+}
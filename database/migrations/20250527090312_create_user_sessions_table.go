@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250527090312CreateUserSessionsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250527090312CreateUserSessionsTable) Signature() string {
+	return "20250527090312_create_user_sessions_table"
+}
+
+// Up Run the migrations.
+func (r *M20250527090312CreateUserSessionsTable) Up() error {
+	if !facades.Schema().HasTable("user_sessions") {
+		return facades.Schema().Create("user_sessions", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("family_id")
+			table.Text("user_agent")
+			table.String("ip_address")
+			table.Timestamp("last_seen_at")
+			table.Timestamp("expires_at")
+			table.Timestamp("revoked_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("family_id")
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250527090312CreateUserSessionsTable) Down() error {
+	return facades.Schema().DropIfExists("user_sessions")
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250603141722AddTwoFactorToUsersTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250603141722AddTwoFactorToUsersTable) Signature() string {
+	return "20250603141722_add_two_factor_to_users_table"
+}
+
+// Up Run the migrations.
+func (r *M20250603141722AddTwoFactorToUsersTable) Up() error {
+	if !facades.Schema().HasColumn("users", "two_factor_secret") {
+		if err := facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.String("two_factor_secret").Default("")
+			table.Timestamp("two_factor_confirmed_at").Nullable()
+			table.BigInteger("two_factor_last_step").Default(0)
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("two_factor_recovery_codes") {
+		return facades.Schema().Create("two_factor_recovery_codes", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("code")
+			table.Timestamp("used_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250603141722AddTwoFactorToUsersTable) Down() error {
+	if err := facades.Schema().DropIfExists("two_factor_recovery_codes"); err != nil {
+		return err
+	}
+
+	if facades.Schema().HasColumn("users", "two_factor_secret") {
+		return facades.Schema().DropColumns("users", []string{"two_factor_secret", "two_factor_confirmed_at", "two_factor_last_step"})
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250610093455CreatePasskeyCredentialsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250610093455CreatePasskeyCredentialsTable) Signature() string {
+	return "20250610093455_create_passkey_credentials_table"
+}
+
+// Up Run the migrations.
+func (r *M20250610093455CreatePasskeyCredentialsTable) Up() error {
+	if !facades.Schema().HasTable("passkey_credentials") {
+		return facades.Schema().Create("passkey_credentials", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("credential_id")
+			table.Text("public_key")
+			table.UnsignedBigInteger("sign_count").Default(0)
+			table.String("name")
+			table.Timestamp("last_used_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("credential_id")
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250610093455CreatePasskeyCredentialsTable) Down() error {
+	return facades.Schema().DropIfExists("passkey_credentials")
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250617101530CreateUserIdentitiesTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250617101530CreateUserIdentitiesTable) Signature() string {
+	return "20250617101530_create_user_identities_table"
+}
+
+// Up Run the migrations.
+func (r *M20250617101530CreateUserIdentitiesTable) Up() error {
+	if !facades.Schema().HasTable("user_identities") {
+		return facades.Schema().Create("user_identities", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("provider")
+			table.String("subject")
+			table.String("email")
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("provider", "subject")
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250617101530CreateUserIdentitiesTable) Down() error {
+	return facades.Schema().DropIfExists("user_identities")
+}
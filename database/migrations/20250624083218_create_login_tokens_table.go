@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250624083218CreateLoginTokensTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250624083218CreateLoginTokensTable) Signature() string {
+	return "20250624083218_create_login_tokens_table"
+}
+
+// Up Run the migrations.
+func (r *M20250624083218CreateLoginTokensTable) Up() error {
+	if !facades.Schema().HasTable("login_tokens") {
+		return facades.Schema().Create("login_tokens", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("token")
+			table.String("code")
+			table.Integer("attempts").Default(0)
+			table.Timestamp("expires_at")
+			table.Timestamp("used_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("token")
+			table.Index("user_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250624083218CreateLoginTokensTable) Down() error {
+	return facades.Schema().DropIfExists("login_tokens")
+}
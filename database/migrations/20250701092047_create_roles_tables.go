@@ -0,0 +1,163 @@
+package migrations
+
+import (
+	"time"
+
+	"github.com/goravel/framework/contracts/database/orm"
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250701092047CreateRolesTables struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250701092047CreateRolesTables) Signature() string {
+	return "20250701092047_create_roles_tables"
+}
+
+// Up Run the migrations.
+func (r *M20250701092047CreateRolesTables) Up() error {
+	if !facades.Schema().HasTable("roles") {
+		if err := facades.Schema().Create("roles", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("name")
+			table.String("description")
+			table.Timestamps()
+
+			table.Unique("name")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("permissions") {
+		if err := facades.Schema().Create("permissions", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("name")
+			table.String("description")
+			table.Timestamps()
+
+			table.Unique("name")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("role_permissions") {
+		if err := facades.Schema().Create("role_permissions", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("role_id")
+			table.UnsignedBigInteger("permission_id")
+
+			table.Foreign("role_id").References("id").On("roles").CascadeOnDelete()
+			table.Foreign("permission_id").References("id").On("permissions").CascadeOnDelete()
+			table.Unique("role_id", "permission_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("user_roles") {
+		if err := facades.Schema().Create("user_roles", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("user_id")
+			table.UnsignedBigInteger("role_id")
+
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Foreign("role_id").References("id").On("roles").CascadeOnDelete()
+			table.Unique("user_id", "role_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Existing users keep working through the default role, so the roles
+	// have to exist before the gate checks them. The rows are the ones known
+	// to this migration, later permissions are added by their own.
+	query := facades.Orm().Query()
+	for _, permission := range [][2]string{
+		{"poll.create", "Create polls"},
+		{"poll.update", "Edit, publish and close own polls"},
+		{"poll.update.any", "Edit, publish and close any poll"},
+		{"poll.delete", "Delete own polls"},
+		{"poll.delete.any", "Delete any poll"},
+		{"poll.view.any", "View any poll with its options and history"},
+		{"vote.cast", "Vote in polls"},
+		{"webhook.manage", "Manage webhooks of own polls"},
+		{"role.manage", "Assign roles to users"},
+	} {
+		if err := insertPermission(query, permission[0], permission[1]); err != nil {
+			return err
+		}
+	}
+
+	for _, role := range []struct {
+		name, description string
+		permissions       []string
+	}{
+		{"admin", "Manages every poll and the roles of users", []string{"poll.create", "poll.update", "poll.update.any",
+			"poll.delete", "poll.delete.any", "poll.view.any", "vote.cast", "webhook.manage", "role.manage"}},
+		{"organizer", "Runs own polls", []string{"poll.create", "poll.update", "poll.delete", "vote.cast", "webhook.manage"}},
+		{"voter", "Votes in polls", []string{"vote.cast"}},
+		{"auditor", "Reads every poll and its history without changing anything", []string{"poll.view.any"}},
+	} {
+		var count int64
+		if err := query.Table("roles").Where("name = ?", role.name).Count(&count); err != nil {
+			return err
+		}
+		// Roles that already exist keep their permissions
+		if count > 0 {
+			continue
+		}
+
+		now := time.Now()
+		if _, err := query.Exec("INSERT INTO roles (name, description, created_at, updated_at) VALUES (?, ?, ?, ?)",
+			role.name, role.description, now, now); err != nil {
+			return err
+		}
+		for _, permission := range role.permissions {
+			if err := grantPermission(query, role.name, permission); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertPermission adds a permission unless it exists
+func insertPermission(query orm.Query, name, description string) error {
+	var count int64
+	if err := query.Table("permissions").Where("name = ?", name).Count(&count); err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	now := time.Now()
+	_, err := query.Exec("INSERT INTO permissions (name, description, created_at, updated_at) VALUES (?, ?, ?, ?)",
+		name, description, now, now)
+	return err
+}
+
+// grantPermission adds a permission to a role unless it has it, nothing
+// happens when either does not exist
+func grantPermission(query orm.Query, role, permission string) error {
+	_, err := query.Exec(`INSERT INTO role_permissions (role_id, permission_id)
+		SELECT roles.id, permissions.id FROM roles, permissions
+		WHERE roles.name = ? AND permissions.name = ? AND NOT EXISTS (
+			SELECT 1 FROM role_permissions WHERE role_id = roles.id AND permission_id = permissions.id
+		)`, role, permission)
+	return err
+}
+
+// Down Reverse the migrations.
+func (r *M20250701092047CreateRolesTables) Down() error {
+	for _, table := range []string{"user_roles", "role_permissions", "permissions", "roles"} {
+		if err := facades.Schema().DropIfExists(table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250708101245CreatePollCollaboratorsTables struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250708101245CreatePollCollaboratorsTables) Signature() string {
+	return "20250708101245_create_poll_collaborators_tables"
+}
+
+// Up Run the migrations.
+func (r *M20250708101245CreatePollCollaboratorsTables) Up() error {
+	if !facades.Schema().HasTable("poll_collaborators") {
+		if err := facades.Schema().Create("poll_collaborators", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("poll_id")
+			table.UnsignedBigInteger("user_id")
+			table.String("role", 20)
+			table.Timestamps()
+
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("poll_id", "user_id")
+			table.Index("user_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("poll_invitations") {
+		return facades.Schema().Create("poll_invitations", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("poll_id")
+			table.String("email")
+			table.String("role", 20)
+			table.String("token")
+			table.UnsignedBigInteger("invited_by")
+			table.Timestamp("expires_at")
+			table.Timestamp("accepted_at").Nullable()
+			table.Timestamps()
+
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Foreign("invited_by").References("id").On("users").CascadeOnDelete()
+			table.Unique("token")
+			table.Index("poll_id", "email")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250708101245CreatePollCollaboratorsTables) Down() error {
+	if err := facades.Schema().DropIfExists("poll_invitations"); err != nil {
+		return err
+	}
+
+	return facades.Schema().DropIfExists("poll_collaborators")
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250715094530CreateOrganizationsTables struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250715094530CreateOrganizationsTables) Signature() string {
+	return "20250715094530_create_organizations_tables"
+}
+
+// Up Run the migrations.
+func (r *M20250715094530CreateOrganizationsTables) Up() error {
+	if !facades.Schema().HasTable("organizations") {
+		if err := facades.Schema().Create("organizations", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("name")
+			table.String("slug")
+			table.Timestamps()
+
+			table.Unique("slug")
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !facades.Schema().HasTable("organization_members") {
+		if err := facades.Schema().Create("organization_members", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("organization_id")
+			table.UnsignedBigInteger("user_id")
+			table.String("role", 20)
+			table.Timestamps()
+
+			table.Foreign("organization_id").References("id").On("organizations").CascadeOnDelete()
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+			table.Unique("organization_id", "user_id")
+			table.Index("user_id")
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Existing polls stay in the personal workspace of their owner
+	if !facades.Schema().HasColumn("polls", "organization_id") {
+		return facades.Schema().Table("polls", func(table schema.Blueprint) {
+			table.UnsignedBigInteger("organization_id").Nullable()
+
+			table.Foreign("organization_id").References("id").On("organizations")
+			table.Index("organization_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250715094530CreateOrganizationsTables) Down() error {
+	if facades.Schema().HasColumn("polls", "organization_id") {
+		if err := facades.Schema().Table("polls", func(table schema.Blueprint) {
+			table.DropColumn("organization_id")
+		}); err != nil {
+			return err
+		}
+	}
+	if err := facades.Schema().DropIfExists("organization_members"); err != nil {
+		return err
+	}
+
+	return facades.Schema().DropIfExists("organizations")
+}
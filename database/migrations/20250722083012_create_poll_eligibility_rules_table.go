@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250722083012CreatePollEligibilityRulesTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250722083012CreatePollEligibilityRulesTable) Signature() string {
+	return "20250722083012_create_poll_eligibility_rules_table"
+}
+
+// Up Run the migrations.
+func (r *M20250722083012CreatePollEligibilityRulesTable) Up() error {
+	if !facades.Schema().HasTable("poll_eligibility_rules") {
+		return facades.Schema().Create("poll_eligibility_rules", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("poll_id")
+			table.Text("allowed_domains")
+			table.UnsignedBigInteger("organization_id").Nullable()
+			table.Integer("min_account_age_days").Default(0)
+			table.Timestamps()
+
+			table.Foreign("poll_id").References("id").On("polls").CascadeOnDelete()
+			table.Foreign("organization_id").References("id").On("organizations").CascadeOnDelete()
+			table.Unique("poll_id")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250722083012CreatePollEligibilityRulesTable) Down() error {
+	return facades.Schema().DropIfExists("poll_eligibility_rules")
+}
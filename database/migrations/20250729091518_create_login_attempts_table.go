@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250729091518CreateLoginAttemptsTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250729091518CreateLoginAttemptsTable) Signature() string {
+	return "20250729091518_create_login_attempts_table"
+}
+
+// Up Run the migrations.
+func (r *M20250729091518CreateLoginAttemptsTable) Up() error {
+	if !facades.Schema().HasTable("login_attempts") {
+		if err := facades.Schema().Create("login_attempts", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("email")
+			table.UnsignedBigInteger("user_id").Nullable()
+			table.String("ip_address", 45)
+			table.Text("user_agent")
+			table.Boolean("successful").Default(false)
+			table.String("reason", 32)
+			table.Timestamps()
+
+			table.Foreign("user_id").References("id").On("users").NullOnDelete()
+			table.Index("email", "created_at")
+			table.Index("ip_address", "created_at")
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Admins seeded before the log existed may read it too
+	query := facades.Orm().Query()
+	if err := insertPermission(query, "login_attempt.view", "Read the login attempt log"); err != nil {
+		return err
+	}
+
+	return grantPermission(query, "admin", "login_attempt.view")
+}
+
+// Down Reverse the migrations.
+func (r *M20250729091518CreateLoginAttemptsTable) Down() error {
+	return facades.Schema().DropIfExists("login_attempts")
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250805102733AddRiskSignalsToVotesTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250805102733AddRiskSignalsToVotesTable) Signature() string {
+	return "20250805102733_add_risk_signals_to_votes_table"
+}
+
+// Up Run the migrations.
+func (r *M20250805102733AddRiskSignalsToVotesTable) Up() error {
+	if !facades.Schema().HasColumn("votes", "ip_hash") {
+		return facades.Schema().Table("votes", func(table schema.Blueprint) {
+			table.String("ip_hash", 64).Default("")
+			table.Text("user_agent").Default("")
+			table.String("fingerprint", 64).Default("")
+			table.Integer("risk_score").Default(0)
+			table.String("risk_signals").Default("")
+			table.Boolean("flagged").Default(false)
+			table.String("review_status", 20).Default("")
+			table.Timestamp("reviewed_at").Nullable()
+
+			table.Index("poll_id", "ip_hash", "created_at")
+			table.Index("poll_id", "fingerprint")
+			table.Index("poll_id", "flagged")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250805102733AddRiskSignalsToVotesTable) Down() error {
+	if facades.Schema().HasColumn("votes", "ip_hash") {
+		return facades.Schema().DropColumns("votes", []string{
+			"ip_hash", "user_agent", "fingerprint", "risk_score", "risk_signals", "flagged", "review_status", "reviewed_at",
+		})
+	}
+
+	return nil
+}
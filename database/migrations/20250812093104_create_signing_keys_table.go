@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250812093104CreateSigningKeysTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250812093104CreateSigningKeysTable) Signature() string {
+	return "20250812093104_create_signing_keys_table"
+}
+
+// Up Run the migrations.
+func (r *M20250812093104CreateSigningKeysTable) Up() error {
+	if !facades.Schema().HasTable("signing_keys") {
+		return facades.Schema().Create("signing_keys", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.String("kid", 64)
+			table.String("algorithm", 16)
+			table.Text("private_key")
+			table.Text("public_key")
+			table.Timestamp("retired_at").Nullable()
+			table.Timestamps()
+
+			table.Unique("kid")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250812093104CreateSigningKeysTable) Down() error {
+	return facades.Schema().DropIfExists("signing_keys")
+}
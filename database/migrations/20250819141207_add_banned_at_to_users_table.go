@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250819141207AddBannedAtToUsersTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250819141207AddBannedAtToUsersTable) Signature() string {
+	return "20250819141207_add_banned_at_to_users_table"
+}
+
+// Up Run the migrations.
+func (r *M20250819141207AddBannedAtToUsersTable) Up() error {
+	if !facades.Schema().HasColumn("users", "banned_at") {
+		return facades.Schema().Table("users", func(table schema.Blueprint) {
+			table.Timestamp("banned_at").Nullable()
+			table.String("ban_reason").Default("")
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250819141207AddBannedAtToUsersTable) Down() error {
+	if facades.Schema().HasColumn("users", "banned_at") {
+		return facades.Schema().DropColumns("users", []string{"banned_at", "ban_reason"})
+	}
+
+	return nil
+}
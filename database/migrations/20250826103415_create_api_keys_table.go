@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/goravel/framework/contracts/database/schema"
+	"github.com/goravel/framework/facades"
+)
+
+type M20250826103415CreateApiKeysTable struct {
+}
+
+// Signature The unique signature for the migration.
+func (r *M20250826103415CreateApiKeysTable) Signature() string {
+	return "20250826103415_create_api_keys_table"
+}
+
+// Up Run the migrations.
+func (r *M20250826103415CreateApiKeysTable) Up() error {
+	if !facades.Schema().HasTable("api_keys") {
+		return facades.Schema().Create("api_keys", func(table schema.Blueprint) {
+			table.BigIncrements("id")
+			table.UnsignedBigInteger("user_id")
+			table.String("name")
+			table.String("prefix", 16)
+			table.String("token_hash", 64)
+			table.String("scopes").Default("")
+			table.Timestamp("expires_at")
+			table.Timestamp("last_used_at").Nullable()
+			table.Timestamp("revoked_at").Nullable()
+			table.Timestamps()
+
+			table.Unique("token_hash")
+			table.Index("user_id")
+			table.Foreign("user_id").References("id").On("users").CascadeOnDelete()
+		})
+	}
+
+	return nil
+}
+
+// Down Reverse the migrations.
+func (r *M20250826103415CreateApiKeysTable) Down() error {
+	return facades.Schema().DropIfExists("api_keys")
+}
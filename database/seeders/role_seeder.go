@@ -0,0 +1,18 @@
+package seeders
+
+import (
+	"evote-be/app/services/rbac"
+)
+
+type RoleSeeder struct {
+}
+
+// Signature The name and signature of the seeder.
+func (s *RoleSeeder) Signature() string {
+	return "RoleSeeder"
+}
+
+// Run executes the seeder logic.
+func (s *RoleSeeder) Run() error {
+	return rbac.Seed()
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/goravel/framework/facades"
 
+	"evote-be/app/services/lifecycle"
 	"evote-be/bootstrap"
 )
 
@@ -28,6 +29,11 @@ func main() {
 	// Start schedule by facades.Schedule
 	go facades.Schedule().Run()
 
+	// Start poll lifecycle timers, rebuilt from the database
+	if err := lifecycle.Start(); err != nil {
+		facades.Log().Errorf("Poll lifecycle start error, retrying in the background: %v", err)
+	}
+
 	// Start queue worker by facades.Queue()
 	go func() {
 		if err := facades.Queue().Worker().Run(); err != nil {
@@ -43,7 +49,7 @@ func main() {
 	}()
 
 	// Create a channel to listen for OS signals
-	quit := make(chan os.Signal)
+	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	// Listen for the OS signal
@@ -55,6 +61,7 @@ func main() {
 		if err := facades.Schedule().Shutdown(); err != nil {
 			facades.Log().Errorf("Schedule Shutdown error: %v", err)
 		}
+		lifecycle.Stop()
 
 		os.Exit(0)
 	}()
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"github.com/goravel/framework/facades"
+	frameworkmiddleware "github.com/goravel/framework/http/middleware"
 
 	"evote-be/app/http/controllers"
 	"evote-be/app/http/middleware"
@@ -12,33 +13,116 @@ func Api() {
 	pollsController := controllers.NewPollsController()
 	optionController := controllers.NewOptionController()
 	userController := controllers.NewUserController()
+	sessionController := controllers.NewSessionController()
+	twoFactorController := controllers.NewTwoFactorController()
+	passkeyController := controllers.NewPasskeyController()
+	oidcController := controllers.NewOIDCController()
 	voteController := controllers.NewVoteController()
+	webhookController := controllers.NewWebhookController()
+	roleController := controllers.NewRoleController()
+	collaboratorController := controllers.NewCollaboratorController()
+	organizationController := controllers.NewOrganizationController()
+	eligibilityController := controllers.NewEligibilityController()
+	loginAttemptController := controllers.NewLoginAttemptController()
+	proofOfWorkController := controllers.NewProofOfWorkController()
+	apiKeyController := controllers.NewApiKeyController()
 
 	// @Group Auth
-	facades.Route().Post("/auth/register", authController.Register)
-	facades.Route().Post("/auth/login", authController.Login)
-	facades.Route().Get("/auth/verify/{token}", authController.Verify)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/register", authController.Register)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/login", authController.Login)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/two-factor/verify", authController.VerifyTwoFactor)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/passkey/options", passkeyController.LoginOptions)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/passkey/verify", passkeyController.Login)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Get("/auth/oidc/{provider}/redirect", oidcController.Redirect)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/oidc/{provider}/callback", oidcController.Callback)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth"), frameworkmiddleware.Throttle("passwordless")).Post("/auth/passwordless/send", authController.SendLoginLink)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/passwordless/verify", authController.LoginWithLink)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/refresh", authController.Refresh)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth"), middleware.Auth()).Post("/auth/logout", authController.Logout)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth"), middleware.VerifyCsrfToken()).Get("/auth/verify/{token}", authController.ConfirmVerify)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth"), middleware.VerifyCsrfToken()).Post("/auth/verify/{token}", authController.Verify)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/verify/resend", authController.ResendVerification)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/password/forgot", authController.ForgotPassword)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("auth")).Post("/auth/password/reset", authController.ResetPassword)
+
+	// @Group Proof of Work
+	facades.Route().Middleware(frameworkmiddleware.Throttle("pow")).Get("/pow/challenge", proofOfWorkController.Challenge)
 
 	// @Group Users
 	facades.Route().Middleware(middleware.Auth()).Put("/users/update", userController.Update)
 	facades.Route().Middleware(middleware.Auth()).Post("/users/avatar", userController.UploadAvatar)
 	facades.Route().Middleware(middleware.Auth()).Get("/users/profile", userController.GetProfile)
+	facades.Route().Middleware(middleware.Auth()).Get("/users/sessions", sessionController.Index)
+	facades.Route().Middleware(middleware.Auth()).Delete("/users/sessions/{id}/delete", sessionController.Delete)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/sessions/revoke-others", sessionController.RevokeOthers)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/two-factor/enable", twoFactorController.Enable)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/two-factor/confirm", twoFactorController.Confirm)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/two-factor/recovery-codes", twoFactorController.RecoveryCodes)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/two-factor/disable", twoFactorController.Disable)
+	facades.Route().Middleware(middleware.Auth()).Get("/users/passkeys", passkeyController.Index)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/passkeys/register/options", passkeyController.RegistrationOptions)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/passkeys/register", passkeyController.Register)
+	facades.Route().Middleware(middleware.Auth()).Delete("/users/passkeys/{id}/delete", passkeyController.Delete)
+	facades.Route().Middleware(middleware.Auth()).Get("/users/api-keys", apiKeyController.Index)
+	facades.Route().Middleware(middleware.Auth()).Post("/users/api-keys/create", apiKeyController.Store)
+	facades.Route().Middleware(middleware.Auth()).Delete("/users/api-keys/{id}/delete", apiKeyController.Delete)
 
 	// @Group Polls
-	facades.Route().Middleware(middleware.Auth()).Get("/polls", pollsController.Index)
-	facades.Route().Middleware(middleware.Auth()).Post("/polls/create", pollsController.Store)
-	facades.Route().Middleware(middleware.Auth()).Get("/polls/{id}", pollsController.Show)
-	facades.Route().Middleware(middleware.Auth()).Put("/polls/{id}/update", pollsController.Update)
-	facades.Route().Middleware(middleware.Auth()).Delete("/polls/{id}/delete", pollsController.Delete)
-	facades.Route().Middleware(middleware.Auth()).Get("/polls/{id}/options", pollsController.GetPollOptions)
-	facades.Route().Middleware(middleware.Auth()).Get("/polls/{id}/generate", pollsController.GeneratePublicPollCode)
-	facades.Route().Get("/polls/public", pollsController.GetPublicPolls)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls", pollsController.Index)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization(), middleware.Can("poll.create")).Post("/polls/create", pollsController.Store)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls/{id}", pollsController.Show)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Put("/polls/{id}/update", pollsController.Update)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Delete("/polls/{id}/delete", pollsController.Delete)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls/{id}/options", pollsController.GetPollOptions)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Get("/polls/{id}/generate", pollsController.GeneratePublicPollCode)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/publish", pollsController.Publish)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/pause", pollsController.Pause)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/resume", pollsController.Resume)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/close", pollsController.Close)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/cancel", pollsController.Cancel)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/polls/{id}/archive", pollsController.Archive)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls/{id}/transitions", pollsController.Transitions)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls/{id}/amendments", pollsController.Amendments)
+	facades.Route().Middleware(middleware.Auth("polls:read"), middleware.Organization()).Get("/polls/{id}/eligibility", eligibilityController.Show)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Put("/polls/{id}/eligibility/update", eligibilityController.Update)
+	facades.Route().Middleware(frameworkmiddleware.Throttle("public_polls"), middleware.ProofOfWork()).Get("/polls/public", pollsController.GetPublicPolls)
+
+	// @Group Collaborators
+	facades.Route().Middleware(middleware.Auth(), middleware.Organization()).Get("/polls/{id}/collaborators", collaboratorController.Index)
+	facades.Route().Middleware(middleware.Auth(), middleware.Organization()).Post("/polls/{id}/collaborators/invite", collaboratorController.Invite)
+	facades.Route().Middleware(middleware.Auth(), middleware.Organization()).Delete("/polls/{id}/collaborators/{user_id}/delete", collaboratorController.Delete)
+	facades.Route().Middleware(middleware.Auth(), middleware.Organization()).Post("/polls/{id}/transfer", collaboratorController.Transfer)
+	facades.Route().Middleware(middleware.Auth()).Post("/polls/invitations/accept", collaboratorController.Accept)
 
 	// @Group Options
-	facades.Route().Middleware(middleware.Auth()).Post("/options/create", optionController.Store)
-	facades.Route().Middleware(middleware.Auth()).Delete("/options/{id}/delete", optionController.Delete)
-	facades.Route().Middleware(middleware.Auth()).Put("/options/{id}/update", optionController.Update)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Post("/options/create", optionController.Store)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Delete("/options/{id}/delete", optionController.Delete)
+	facades.Route().Middleware(middleware.Auth("polls:write"), middleware.Organization()).Put("/options/{id}/update", optionController.Update)
+
+	// @Group Organizations
+	facades.Route().Middleware(middleware.Auth()).Get("/organizations", organizationController.Index)
+	facades.Route().Middleware(middleware.Auth()).Post("/organizations/create", organizationController.Store)
+	facades.Route().Middleware(middleware.Auth()).Get("/organizations/{id}/members", organizationController.Members)
+	facades.Route().Middleware(middleware.Auth()).Post("/organizations/{id}/members/add", organizationController.AddMember)
+	facades.Route().Middleware(middleware.Auth()).Put("/organizations/{id}/members/{user_id}/update", organizationController.UpdateMember)
+	facades.Route().Middleware(middleware.Auth()).Delete("/organizations/{id}/members/{user_id}/delete", organizationController.RemoveMember)
 
 	// @Group Votes
-	facades.Route().Middleware(middleware.Auth()).Post("/votes/create", voteController.Store)
+	facades.Route().Middleware(middleware.Auth("votes:write"), frameworkmiddleware.Throttle("votes"), middleware.Organization(), middleware.Can("vote.cast")).Post("/votes/create", voteController.Store)
+	facades.Route().Middleware(middleware.Auth("votes:read"), middleware.Organization()).Get("/polls/{id}/votes/flagged", voteController.Flagged)
+	facades.Route().Middleware(middleware.Auth("votes:write"), middleware.Organization()).Put("/polls/{id}/votes/{vote_id}/review", voteController.Review)
+
+	// @Group Webhooks
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("webhook.manage")).Get("/webhooks", webhookController.Index)
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("webhook.manage")).Post("/webhooks/create", webhookController.Store)
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("webhook.manage")).Delete("/webhooks/{id}/delete", webhookController.Delete)
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("webhook.manage")).Get("/webhooks/{id}/deliveries", webhookController.Deliveries)
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("webhook.manage")).Post("/webhooks/deliveries/{id}/redeliver", webhookController.Redeliver)
+
+	// @Group Roles
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("role.manage")).Get("/roles", roleController.Index)
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("role.manage")).Put("/users/{id}/roles/update", roleController.UpdateUserRoles)
+
+	// @Group Login Attempts
+	facades.Route().Middleware(middleware.Auth(), middleware.Can("login_attempt.view")).Get("/login-attempts", loginAttemptController.Index)
 }
@@ -15,6 +15,10 @@ func Web() {
 		})
 	})
 
+	// Public keys of access tokens
+	jwksController := controllers.NewJwksController()
+	facades.Route().Get("/.well-known/jwks.json", jwksController.Index)
+
 	// Swagger
 	swaggerController := controllers.NewSwaggerController()
 	facades.Route().Get("/swagger/*any", swaggerController.Index)
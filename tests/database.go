@@ -0,0 +1,45 @@
+package tests
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/goravel/framework/contracts"
+	databaseorm "github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+)
+
+// UseSqlite points the ORM and the schema builder at an empty SQLite database
+// with the given tables until the test finishes, for tests of services that
+// query the database
+func (r *TestCase) UseSqlite(t *testing.T, schema ...string) {
+	facades.Config().Add("database.connections.testing", map[string]any{
+		"driver":   "sqlite",
+		"database": filepath.Join(t.TempDir(), "testing.db"),
+		"prefix":   "",
+		"singular": false,
+	})
+	orm, err := databaseorm.BuildOrm(context.Background(), facades.Config(), "testing", facades.Log(), facades.App().Refresh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, statement := range schema {
+		if _, err := orm.Query().Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous := facades.Orm()
+	facades.App().Refresh(contracts.BindingOrm)
+	facades.App().Instance(contracts.BindingOrm, orm)
+	facades.App().Refresh(contracts.BindingSchema)
+	t.Cleanup(func() {
+		if db, err := orm.DB(); err == nil {
+			_ = db.Close()
+		}
+		facades.App().Refresh(contracts.BindingOrm)
+		facades.App().Instance(contracts.BindingOrm, previous)
+		facades.App().Refresh(contracts.BindingSchema)
+	})
+}
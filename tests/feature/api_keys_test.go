@@ -0,0 +1,150 @@
+package feature
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/support/carbon"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/http/middleware"
+	"evote-be/app/models"
+	"evote-be/app/services/apikeys"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/users"
+	"evote-be/tests"
+)
+
+type ApiKeysTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestApiKeysTestSuite(t *testing.T) {
+	suite.Run(t, new(ApiKeysTestSuite))
+}
+
+func (s *ApiKeysTestSuite) SetupSuite() {
+	facades.Route().Middleware(middleware.Auth(apikeys.PollsRead)).Get("/testing/api-key", func(ctx contractshttp.Context) contractshttp.Response {
+		user, _ := users.Current(ctx)
+		return ctx.Response().Success().Json(contractshttp.Json{"name": user.Name})
+	})
+}
+
+func (s *ApiKeysTestSuite) TestIsKey() {
+	s.True(apikeys.IsKey("evk_0123456789abcdef"))
+	s.False(apikeys.IsKey("eyJhbGciOiJSUzI1NiJ9.e30.sig"))
+}
+
+func (s *ApiKeysTestSuite) TestValid() {
+	now := time.Now()
+	key := models.ApiKeys{Model: orm.Model{ID: 1}, ExpiresAt: now.Add(time.Hour)}
+	s.True(apikeys.Valid(key, now))
+	s.False(apikeys.Valid(key, now.Add(2*time.Hour)))
+	s.False(apikeys.Valid(models.ApiKeys{ExpiresAt: now.Add(time.Hour)}, now))
+
+	key.RevokedAt = &now
+	s.False(apikeys.Valid(key, now))
+}
+
+func (s *ApiKeysTestSuite) TestAllows() {
+	key := models.ApiKeys{Scopes: "polls:read,votes:write"}
+	s.True(apikeys.Allows(key, apikeys.PollsRead))
+	s.True(apikeys.Allows(key, apikeys.PollsRead, apikeys.VotesWrite))
+	s.False(apikeys.Allows(key, apikeys.PollsWrite))
+	s.False(apikeys.Allows(key, apikeys.PollsRead, apikeys.VotesRead))
+}
+
+func (s *ApiKeysTestSuite) TestExpiresAt() {
+	facades.Config().Add("auth.api_keys.expire", 90)
+	facades.Config().Add("auth.api_keys.max_expire", 365)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expiresAt, err := apikeys.ExpiresAt(0, now)
+	s.Require().NoError(err)
+	s.Equal(now.AddDate(0, 0, 90), expiresAt)
+
+	expiresAt, err = apikeys.ExpiresAt(7, now)
+	s.Require().NoError(err)
+	s.Equal(now.AddDate(0, 0, 7), expiresAt)
+
+	_, err = apikeys.ExpiresAt(366, now)
+	s.ErrorIs(err, apikeys.ErrExpiry)
+}
+
+func (s *ApiKeysTestSuite) TestKeyRejectedWithoutScopes() {
+	resp, err := s.Http(s.T()).WithHeader("Authorization", "Bearer evk_unknown").Get("/users/profile")
+	s.Require().NoError(err)
+	resp.AssertForbidden()
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("API_KEY_NOT_ALLOWED", body["errors"])
+}
+
+func (s *ApiKeysTestSuite) TestKeyWithScope() {
+	plain := cacheApiKey(s.Require(), 424243, "polls:read", time.Now().Add(time.Hour))
+
+	resp, err := s.Http(s.T()).WithHeader("Authorization", "Bearer "+plain).Get("/testing/api-key")
+	s.Require().NoError(err)
+	resp.AssertStatus(http.StatusOK)
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("Integration", body["name"])
+}
+
+func (s *ApiKeysTestSuite) TestKeyMissingScope() {
+	plain := cacheApiKey(s.Require(), 424244, "votes:read", time.Now().Add(time.Hour))
+
+	resp, err := s.Http(s.T()).WithHeader("Authorization", "Bearer "+plain).Get("/testing/api-key")
+	s.Require().NoError(err)
+	resp.AssertForbidden()
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("INSUFFICIENT_SCOPE", body["errors"])
+}
+
+func (s *ApiKeysTestSuite) TestExpiredKey() {
+	plain := cacheApiKey(s.Require(), 424245, "polls:read", time.Now().Add(-time.Minute))
+
+	resp, err := s.Http(s.T()).WithHeader("Authorization", "Bearer "+plain).Get("/testing/api-key")
+	s.Require().NoError(err)
+	resp.AssertStatus(http.StatusUnauthorized)
+}
+
+func (s *ApiKeysTestSuite) TestUnknownKeyNotCached() {
+	s.UseSqlite(s.T(), `CREATE TABLE api_keys (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, name text, prefix text,
+		token_hash text, scopes text, expires_at datetime, last_used_at datetime, revoked_at datetime, created_at datetime, updated_at datetime)`)
+
+	plain := "evk_unknown"
+	_, err := apikeys.Authenticate(plain)
+	s.ErrorIs(err, apikeys.ErrInvalidKey)
+	s.False(facades.Cache().Has("auth:api_key:" + tokens.Hash(plain)))
+}
+
+// cacheApiKey puts an API key and its verified user into the cache, so that
+// the auth middleware accepts it without the database
+func cacheApiKey(r *require.Assertions, id uint, scopes string, expiresAt time.Time) string {
+	plain := "evk_test" + tokens.Hash(strconv.FormatUint(uint64(id), 10)+scopes+expiresAt.String())
+	hash := tokens.Hash(plain)
+	key := models.ApiKeys{Model: orm.Model{ID: id}, UserID: id, Scopes: scopes, TokenHash: hash, ExpiresAt: expiresAt}
+	r.NoError(facades.Cache().Put("auth:api_key:"+hash, key, time.Minute))
+	r.NoError(facades.Cache().Put("auth:api_key_used:"+hash, true, time.Minute))
+
+	now := time.Now()
+	timestamps := orm.Timestamps{CreatedAt: carbon.NewDateTime(carbon.Now()), UpdatedAt: carbon.NewDateTime(carbon.Now())}
+	data, err := json.Marshal(models.User{Model: orm.Model{ID: id, Timestamps: timestamps}, Name: "Integration", EmailVerifiedAt: &now})
+	r.NoError(err)
+	r.NoError(facades.Cache().Put("auth:user:"+strconv.FormatUint(uint64(id), 10), string(data), time.Minute))
+
+	return plain
+}
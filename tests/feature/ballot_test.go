@@ -0,0 +1,132 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/ballot"
+	"evote-be/tests"
+)
+
+type BallotTestSuite struct {
+	suite.Suite
+	tests.TestCase
+	rules ballot.Rules
+}
+
+func TestBallotTestSuite(t *testing.T) {
+	suite.Run(t, new(BallotTestSuite))
+}
+
+func (s *BallotTestSuite) SetupTest() {
+	s.rules = ballot.Rules{
+		Threshold:              50,
+		IPWindow:               10 * time.Minute,
+		IPVotes:                3,
+		IPWeight:               40,
+		FingerprintVotes:       1,
+		FingerprintWeight:      50,
+		NewAccount:             24 * time.Hour,
+		NewAccountWeight:       30,
+		MissingUserAgentWeight: 20,
+	}
+}
+
+func (s *BallotTestSuite) TestOrdinaryVoteIsNotFlagged() {
+	assessment := ballot.Score(ballot.Observation{
+		VotesFromIP: 1,
+		AccountAge:  30 * 24 * time.Hour,
+		UserAgent:   "Mozilla/5.0",
+	}, s.rules)
+
+	s.Equal(0, assessment.Score)
+	s.Empty(assessment.Signals)
+	s.False(assessment.Flagged)
+}
+
+func (s *BallotTestSuite) TestSingleWeakSignalStaysBelowThreshold() {
+	assessment := ballot.Score(ballot.Observation{
+		AccountAge: time.Hour,
+		UserAgent:  "Mozilla/5.0",
+	}, s.rules)
+
+	s.Equal(30, assessment.Score)
+	s.Equal([]string{ballot.SignalNewAccount}, assessment.Signals)
+	s.False(assessment.Flagged)
+}
+
+func (s *BallotTestSuite) TestThrowawayAccountsFromOneIPAreFlagged() {
+	assessment := ballot.Score(ballot.Observation{
+		VotesFromIP: 3,
+		AccountAge:  time.Hour,
+		UserAgent:   "Mozilla/5.0",
+	}, s.rules)
+
+	s.Equal(70, assessment.Score)
+	s.Equal([]string{ballot.SignalIPBurst, ballot.SignalNewAccount}, assessment.Signals)
+	s.True(assessment.Flagged)
+}
+
+func (s *BallotTestSuite) TestSharedFingerprintIsFlagged() {
+	assessment := ballot.Score(ballot.Observation{
+		VotesWithFingerprint: 1,
+		AccountAge:           30 * 24 * time.Hour,
+	}, s.rules)
+
+	s.Equal([]string{ballot.SignalSharedFingerprint, ballot.SignalMissingUserAgent}, assessment.Signals)
+	s.True(assessment.Flagged)
+}
+
+func (s *BallotTestSuite) TestZeroWeightTurnsHeuristicOff() {
+	s.rules.IPWeight = 0
+	assessment := ballot.Score(ballot.Observation{
+		VotesFromIP: 10,
+		AccountAge:  30 * 24 * time.Hour,
+		UserAgent:   "Mozilla/5.0",
+	}, s.rules)
+
+	s.Empty(assessment.Signals)
+	s.False(assessment.Flagged)
+}
+
+func (s *BallotTestSuite) TestMetadataIsHashed() {
+	metadata := ballot.NewMetadata("203.0.113.7", " Mozilla/5.0 ", "")
+
+	s.Len(metadata.IPHash, 64)
+	s.NotContains(metadata.IPHash, "203.0.113.7")
+	s.Equal(metadata.IPHash, ballot.Hash("203.0.113.7"))
+	s.Equal("Mozilla/5.0", metadata.UserAgent)
+	s.Empty(metadata.Fingerprint)
+}
+
+func (s *BallotTestSuite) TestReviewNeedsAnOpenPoll() {
+	s.UseSqlite(s.T(),
+		`CREATE TABLE options (id integer PRIMARY KEY AUTOINCREMENT, name text, desc text, avatar text, poll_id integer,
+			votes_count integer, created_at datetime, updated_at datetime, deleted_at datetime)`,
+		`CREATE TABLE votes (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, poll_id integer, option_id integer, review_status text,
+			reviewed_at datetime, created_at datetime, updated_at datetime, deleted_at datetime)`,
+		`INSERT INTO options (id, poll_id, votes_count) VALUES (1, 1, 3)`,
+		`INSERT INTO votes (id, user_id, poll_id, option_id, review_status) VALUES (1, 2, 1, 1, 'quarantined')`,
+	)
+	vote := models.Votes{Model: orm.Model{ID: 1}, UserID: 2, PollID: 1, OptionID: 1, ReviewStatus: models.VoteQuarantined}
+
+	// The results of a poll that is not running anymore, or yet, stay as they are
+	for _, status := range []models.Status{models.Draft, models.Scheduled, models.Done, models.Cancelled, models.Archived} {
+		_, err := ballot.Review(models.Polls{Model: orm.Model{ID: 1}, Status: status}, vote, models.VoteApproved)
+		s.ErrorIs(err, ballot.ErrPollClosed, status)
+	}
+	var option models.Options
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", 1).First(&option))
+	s.Equal(uint(3), option.VotesCount)
+
+	reviewed, err := ballot.Review(models.Polls{Model: orm.Model{ID: 1}, Status: models.Paused}, vote, models.VoteApproved)
+	s.Require().NoError(err)
+	s.Equal(models.VoteApproved, reviewed.ReviewStatus)
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", 1).First(&option))
+	s.Equal(uint(4), option.VotesCount)
+}
@@ -0,0 +1,33 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/collaborators"
+	"evote-be/tests"
+)
+
+type CollaboratorsTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestCollaboratorsTestSuite(t *testing.T) {
+	suite.Run(t, new(CollaboratorsTestSuite))
+}
+
+func (s *CollaboratorsTestSuite) TestCanEdit() {
+	s.True(models.CollaboratorOwner.CanEdit())
+	s.True(models.CollaboratorEditor.CanEdit())
+	s.False(models.CollaboratorViewer.CanEdit())
+	s.False(models.CollaboratorRole("").CanEdit())
+}
+
+func (s *CollaboratorsTestSuite) TestPrimaryOwnerNeedsNoGrant() {
+	role, err := collaborators.RoleOf(models.Polls{UserID: 7}, 7)
+	s.Require().NoError(err)
+	s.Equal(models.CollaboratorOwner, role)
+}
@@ -0,0 +1,69 @@
+package feature
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/eligibility"
+	"evote-be/tests"
+)
+
+type EligibilityTestSuite struct {
+	suite.Suite
+	tests.TestCase
+	now time.Time
+}
+
+func TestEligibilityTestSuite(t *testing.T) {
+	suite.Run(t, new(EligibilityTestSuite))
+}
+
+func (s *EligibilityTestSuite) SetupTest() {
+	s.now = time.Date(2025, 7, 22, 12, 0, 0, 0, time.UTC)
+}
+
+func (s *EligibilityTestSuite) voter(email string, age time.Duration, member bool) eligibility.Voter {
+	return eligibility.Voter{
+		Email:     email,
+		CreatedAt: s.now.Add(-age),
+		Member: func(organizationID uint) (bool, error) {
+			return member, nil
+		},
+	}
+}
+
+func (s *EligibilityTestSuite) TestNoRules() {
+	s.NoError(eligibility.Check(models.PollEligibilityRules{}, s.voter("jane@example.com", 0, false), s.now))
+}
+
+func (s *EligibilityTestSuite) TestAllowedDomains() {
+	rules := models.PollEligibilityRules{AllowedDomains: "ourcompany.com, OurCompany.co.id"}
+
+	s.NoError(eligibility.Check(rules, s.voter("Jane@OurCompany.com", 0, false), s.now))
+	s.NoError(eligibility.Check(rules, s.voter("jane@ourcompany.co.id", 0, false), s.now))
+	s.ErrorIs(eligibility.Check(rules, s.voter("jane@sub.ourcompany.com", 0, false), s.now), eligibility.ErrDomainNotAllowed)
+	s.ErrorIs(eligibility.Check(rules, s.voter("jane@example.com", 0, false), s.now), eligibility.ErrDomainNotAllowed)
+}
+
+func (s *EligibilityTestSuite) TestAccountAge() {
+	rules := models.PollEligibilityRules{MinAccountAgeDays: 7}
+
+	s.NoError(eligibility.Check(rules, s.voter("jane@example.com", 8*24*time.Hour, false), s.now))
+	s.ErrorIs(eligibility.Check(rules, s.voter("jane@example.com", 6*24*time.Hour, false), s.now), eligibility.ErrAccountTooNew)
+}
+
+func (s *EligibilityTestSuite) TestOrganizationMembership() {
+	organizationID := uint(3)
+	rules := models.PollEligibilityRules{OrganizationID: &organizationID}
+
+	s.NoError(eligibility.Check(rules, s.voter("jane@example.com", 0, true), s.now))
+
+	err := eligibility.Check(rules, s.voter("jane@example.com", 0, false), s.now)
+	var ineligible *eligibility.Error
+	s.Require().True(errors.As(err, &ineligible))
+	s.Equal("ORGANIZATION_MEMBERSHIP_REQUIRED", ineligible.Code)
+}
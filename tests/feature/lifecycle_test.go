@@ -0,0 +1,139 @@
+package feature
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/lifecycle"
+	"evote-be/tests"
+)
+
+// lifecycleTables are the tables the scheduler reads and writes, the
+// analytics log counts the events fired for a poll
+var lifecycleTables = []string{
+	`CREATE TABLE polls (id integer PRIMARY KEY AUTOINCREMENT, title text, description text, status text, start_date datetime,
+		end_date datetime, code text, user_id integer, organization_id integer, created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`CREATE TABLE poll_transitions (id integer PRIMARY KEY AUTOINCREMENT, poll_id integer, from_status text, to_status text, reason text,
+		user_id integer, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE analytics_events (id integer PRIMARY KEY AUTOINCREMENT, event text, poll_id integer, option_id integer,
+		created_at datetime, updated_at datetime)`,
+}
+
+type LifecycleTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
+
+func (s *LifecycleTestSuite) SetupTest() {
+	s.UseSqlite(s.T(), lifecycleTables...)
+	s.T().Cleanup(lifecycle.Stop)
+}
+
+// createPoll stores a poll with the given status and dates
+func (s *LifecycleTestSuite) createPoll(status models.Status, start, end time.Time) models.Polls {
+	poll := models.Polls{Title: "Board election", Status: status, StartDate: start, EndDate: end, UserID: 1}
+	s.Require().NoError(facades.Orm().Query().Create(&poll))
+
+	return poll
+}
+
+func (s *LifecycleTestSuite) status(pollID uint) models.Status {
+	var poll models.Polls
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", pollID).First(&poll))
+
+	return poll.Status
+}
+
+func (s *LifecycleTestSuite) count(model any, query string, args ...any) int64 {
+	var count int64
+	s.Require().NoError(facades.Orm().Query().Model(model).Where(query, args...).Count(&count))
+
+	return count
+}
+
+func (s *LifecycleTestSuite) TestRebuildSchedulesWithinTheHorizon() {
+	horizon := facades.Config().GetInt("poll.scheduler.horizon", 60)
+	defer facades.Config().Add("poll.scheduler.horizon", horizon)
+	facades.Config().Add("poll.scheduler.horizon", 0)
+
+	upcoming := s.createPoll(models.Scheduled, time.Now().Add(100*time.Millisecond), time.Now().Add(2*time.Hour))
+	overdue := s.createPoll(models.Active, time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+
+	// Overdue transitions run right away, the start is past the horizon
+	s.Require().NoError(lifecycle.Start())
+	s.Eventually(func() bool { return s.status(overdue.ID) == models.Done }, time.Second, 10*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+	s.Equal(models.Scheduled, s.status(upcoming.ID))
+
+	facades.Config().Add("poll.scheduler.horizon", 60)
+	s.Require().NoError(lifecycle.Rebuild())
+	s.Eventually(func() bool { return s.status(upcoming.ID) == models.Active }, time.Second, 10*time.Millisecond)
+}
+
+func (s *LifecycleTestSuite) TestScheduleFollowsTheDates() {
+	s.Require().NoError(lifecycle.Start())
+
+	poll := s.createPoll(models.Scheduled, time.Now().Add(2*time.Hour), time.Now().Add(3*time.Hour))
+	lifecycle.Schedule(poll)
+
+	// The start date was moved forward
+	poll.StartDate = time.Now().Add(100 * time.Millisecond)
+	s.Require().NoError(facades.Orm().Query().Save(&poll))
+	lifecycle.Schedule(poll)
+	s.Eventually(func() bool { return s.status(poll.ID) == models.Active }, time.Second, 10*time.Millisecond)
+}
+
+func (s *LifecycleTestSuite) TestCancelStopsTheTimers() {
+	s.Require().NoError(lifecycle.Start())
+
+	poll := s.createPoll(models.Scheduled, time.Now().Add(100*time.Millisecond), time.Now().Add(time.Hour))
+	lifecycle.Schedule(poll)
+	lifecycle.Cancel(poll.ID)
+
+	time.Sleep(300 * time.Millisecond)
+	s.Equal(models.Scheduled, s.status(poll.ID))
+}
+
+func (s *LifecycleTestSuite) TestConcurrentStartsTransitionOnce() {
+	poll := s.createPoll(models.Scheduled, time.Now().Add(-time.Minute), time.Now().Add(time.Hour))
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		started int
+	)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ok, err := lifecycle.StartPoll(poll.ID)
+			s.NoError(err)
+			if ok {
+				mu.Lock()
+				started++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	s.Equal(1, started)
+	s.Equal(models.Active, s.status(poll.ID))
+	s.Equal(int64(1), s.count(&models.PollTransitions{}, "poll_id = ?", poll.ID))
+	s.Equal(int64(1), s.count(&models.AnalyticsEvents{}, "poll_id = ? AND event = ?", poll.ID, "poll_started"))
+
+	// A poll that already started is left alone
+	ok, err := lifecycle.StartPoll(poll.ID)
+	s.Require().NoError(err)
+	s.False(ok)
+	s.Equal(int64(1), s.count(&models.PollTransitions{}, "poll_id = ?", poll.ID))
+}
@@ -0,0 +1,54 @@
+package feature
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goravel/framework/contracts/event"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/listeners"
+	"evote-be/tests"
+)
+
+type ListenersTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestListenersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListenersTestSuite))
+}
+
+// recordingListener records its calls and fails with err
+type recordingListener struct {
+	calls int
+	err   error
+}
+
+func (r *recordingListener) Signature() string {
+	return "recording"
+}
+
+func (r *recordingListener) Queue(args ...any) event.Queue {
+	return event.Queue{}
+}
+
+func (r *recordingListener) Handle(args ...any) error {
+	r.calls++
+	return r.err
+}
+
+func (s *ListenersTestSuite) TestIsolateKeepsTheChainRunning() {
+	failing := &recordingListener{err: errors.New("mail server down")}
+	next := &recordingListener{}
+
+	isolated := listeners.Isolate(failing, next)
+	s.Require().Len(isolated, 2)
+	for _, listener := range isolated {
+		s.NoError(listener.Handle(uint(1)))
+	}
+	s.Equal(1, failing.calls)
+	s.Equal(1, next.calls)
+	s.Equal("recording", isolated[0].Signature())
+}
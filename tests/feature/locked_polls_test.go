@@ -0,0 +1,124 @@
+package feature
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/tests"
+)
+
+// lockedPollTables hold an active poll of user 1 with one option, its content
+// is locked because voting has begun
+var lockedPollTables = []string{
+	`CREATE TABLE polls (id integer PRIMARY KEY AUTOINCREMENT, title text, description text, status text, start_date datetime,
+		end_date datetime, code text, user_id integer, organization_id integer, created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`CREATE TABLE options (id integer PRIMARY KEY AUTOINCREMENT, name text, desc text, avatar text, poll_id integer,
+		votes_count integer, created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`CREATE TABLE votes (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, poll_id integer, option_id integer,
+		created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`CREATE TABLE poll_amendments (id integer PRIMARY KEY AUTOINCREMENT, poll_id integer, field text, old_value text, new_value text,
+		reason text, user_id integer, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE analytics_events (id integer PRIMARY KEY AUTOINCREMENT, event text, poll_id integer, option_id integer,
+		created_at datetime, updated_at datetime)`,
+	`INSERT INTO polls (id, title, description, status, start_date, end_date, user_id)
+		VALUES (1, 'Board election', 'Elect the board', 'active', '2025-01-01 00:00:00', '2030-01-01 00:00:00', 1)`,
+	`INSERT INTO options (id, name, poll_id, votes_count) VALUES (1, 'Jane', 1, 0)`,
+}
+
+type LockedPollsTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestLockedPollsTestSuite(t *testing.T) {
+	suite.Run(t, new(LockedPollsTestSuite))
+}
+
+func (s *LockedPollsTestSuite) SetupTest() {
+	s.UseSqlite(s.T(), lockedPollTables...)
+
+	// The owner may edit own polls
+	s.Require().NoError(facades.Cache().Put("auth:permissions:1", `{"poll.update":true}`, time.Minute))
+	s.T().Cleanup(func() { facades.Cache().Forget("auth:permissions:1") })
+}
+
+// request sends a JSON body, see send
+func (s *LockedPollsTestSuite) request(method, uri, body string) contractstesting.TestResponse {
+	return s.send(method, uri, "application/json", strings.NewReader(body))
+}
+
+// send sends a body of the content type with an API key of the owner of the poll
+func (s *LockedPollsTestSuite) send(method, uri, contentType string, body io.Reader) contractstesting.TestResponse {
+	request := s.Http(s.T()).WithHeaders(map[string]string{
+		"Authorization": "Bearer " + cacheApiKey(s.Require(), 1, "polls:write", time.Now().Add(time.Hour)),
+		"Content-Type":  contentType,
+	})
+
+	var (
+		resp contractstesting.TestResponse
+		err  error
+	)
+	switch method {
+	case "PUT":
+		resp, err = request.Put(uri, body)
+	case "DELETE":
+		resp, err = request.Delete(uri, body)
+	}
+	s.Require().NoError(err)
+
+	return resp
+}
+
+func (s *LockedPollsTestSuite) errors(resp contractstesting.TestResponse) any {
+	body, err := resp.Json()
+	s.Require().NoError(err)
+
+	return body["errors"]
+}
+
+func (s *LockedPollsTestSuite) TestOptionsCannotChange() {
+	// Options are updated with a form, as it may carry an avatar
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	s.Require().NoError(writer.WriteField("name", "John"))
+	s.Require().NoError(writer.Close())
+	s.Equal("POLL_LOCKED", s.errors(s.send("PUT", "/options/1/update", writer.FormDataContentType(), &form).AssertStatus(409)))
+	s.Equal("POLL_LOCKED", s.errors(s.request("DELETE", "/options/1/delete", "").AssertStatus(409)))
+
+	var option models.Options
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", 1).First(&option))
+	s.Equal("Jane", option.Name)
+}
+
+func (s *LockedPollsTestSuite) TestEndDateCanOnlyBeExtended() {
+	for _, endDate := range []string{"2029-12-31T00:00:00Z", "2030-01-01T00:00:00Z"} {
+		resp := s.request("PUT", "/polls/1/update", `{"end_date":"`+endDate+`","reason":"typo"}`).AssertStatus(422)
+		s.Equal("END_DATE_NOT_EXTENDED", s.errors(resp), endDate)
+	}
+
+	var poll models.Polls
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", 1).First(&poll))
+	s.Equal(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), poll.EndDate.UTC())
+}
+
+func (s *LockedPollsTestSuite) TestExtendingTheEndDateNeedsAReason() {
+	resp := s.request("PUT", "/polls/1/update", `{"end_date":"2030-02-01T00:00:00Z"}`).AssertBadRequest()
+	s.Equal("reason is required to amend a poll once voting has begun", s.errors(resp))
+
+	s.request("PUT", "/polls/1/update", `{"end_date":"2030-02-01T00:00:00Z","reason":"More time to vote"}`).AssertOk()
+
+	var amendments []models.PollAmendments
+	s.Require().NoError(facades.Orm().Query().Where("poll_id = ?", 1).Find(&amendments))
+	s.Require().Len(amendments, 1)
+	s.Equal("end_date", amendments[0].Field)
+	s.Equal("More time to vote", amendments[0].Reason)
+}
@@ -0,0 +1,195 @@
+package feature
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/contracts/event"
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/events"
+	"evote-be/app/models"
+	"evote-be/app/services/lockout"
+	"evote-be/tests"
+)
+
+type LockoutTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestLockoutTestSuite(t *testing.T) {
+	suite.Run(t, new(LockoutTestSuite))
+}
+
+func (s *LockoutTestSuite) TestBackoffDoublesUpToCeiling() {
+	base := 250 * time.Millisecond
+	ceiling := 4 * time.Second
+
+	s.Equal(time.Duration(0), lockout.Backoff(0, base, ceiling))
+	s.Equal(250*time.Millisecond, lockout.Backoff(1, base, ceiling))
+	s.Equal(500*time.Millisecond, lockout.Backoff(2, base, ceiling))
+	s.Equal(time.Second, lockout.Backoff(3, base, ceiling))
+	s.Equal(4*time.Second, lockout.Backoff(5, base, ceiling))
+	s.Equal(4*time.Second, lockout.Backoff(100, base, ceiling))
+}
+
+func (s *LockoutTestSuite) TestBackoffWithoutDelay() {
+	s.Equal(time.Duration(0), lockout.Backoff(3, 0, 4*time.Second))
+}
+
+func (s *LockoutTestSuite) TestNormalize() {
+	s.Equal("voter@example.com", lockout.Normalize("  Voter@Example.COM "))
+}
+
+func (s *LockoutTestSuite) TestNotLockedWithoutFailures() {
+	s.Equal(time.Duration(0), lockout.LockedFor(lockout.Attempt{Email: "nobody@example.com", IPAddress: "203.0.113.7"}))
+}
+
+// loginTables hold the login log and a verified user with the password secret
+var loginTables = []string{
+	`CREATE TABLE login_attempts (id integer PRIMARY KEY AUTOINCREMENT, email text, user_id integer, ip_address text, user_agent text,
+		successful numeric, reason text, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE users (id integer PRIMARY KEY AUTOINCREMENT, name text, email text, password text, email_verified_at datetime,
+		two_factor_confirmed_at datetime, banned_at datetime, created_at datetime, updated_at datetime, deleted_at datetime)`,
+}
+
+// configure changes a config value until the test finishes
+func (s *LockoutTestSuite) configure(key string, value any) {
+	previous := facades.Config().Get(key)
+	facades.Config().Add(key, value)
+	s.T().Cleanup(func() { facades.Config().Add(key, previous) })
+}
+
+// useLoginTables sets up the login log, failures are counted without delays
+// and lock after three
+func (s *LockoutTestSuite) useLoginTables() {
+	s.UseSqlite(s.T(), loginTables...)
+	s.configure("auth.lockout.max_attempts", 3)
+	s.configure("auth.lockout.delay", 0)
+}
+
+func (s *LockoutTestSuite) fail(attempt lockout.Attempt, times int) {
+	for range times {
+		_, err := lockout.Fail(attempt)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *LockoutTestSuite) TestFailuresWithinTheWindowLock() {
+	s.useLoginTables()
+	attempt := lockout.Attempt{Email: "window@example.com", IPAddress: "203.0.113.10"}
+
+	// Failures before the window don't count
+	_, err := facades.Orm().Query().Exec(`INSERT INTO login_attempts (email, ip_address, successful, reason, created_at)
+		VALUES (?, ?, false, ?, ?), (?, ?, false, ?, ?)`,
+		attempt.Email, attempt.IPAddress, models.LoginInvalidCredentials, time.Now().Add(-time.Hour),
+		attempt.Email, attempt.IPAddress, models.LoginInvalidCredentials, time.Now().Add(-time.Hour))
+	s.Require().NoError(err)
+
+	s.fail(attempt, 2)
+	s.Equal(time.Duration(0), lockout.LockedFor(attempt))
+
+	s.fail(lockout.Attempt{Email: " Window@Example.com", IPAddress: "203.0.113.11"}, 1)
+	s.InDelta(float64(15*time.Minute), float64(lockout.LockedFor(attempt)), float64(time.Second))
+}
+
+func (s *LockoutTestSuite) TestSuccessResetsTheFailures() {
+	s.useLoginTables()
+	attempt := lockout.Attempt{Email: "reset@example.com", IPAddress: "203.0.113.20"}
+
+	s.fail(attempt, 2)
+	s.Require().NoError(lockout.Succeed(attempt))
+	s.fail(attempt, 2)
+	s.Equal(time.Duration(0), lockout.LockedFor(attempt))
+
+	s.fail(attempt, 1)
+	s.Greater(lockout.LockedFor(attempt), time.Duration(0))
+}
+
+func (s *LockoutTestSuite) TestLockedForReturnsTheRemainingTime() {
+	s.useLoginTables()
+	s.configure("auth.lockout.duration", 10)
+	attempt := lockout.Attempt{Email: "remaining@example.com", IPAddress: "203.0.113.30"}
+
+	s.fail(attempt, 3)
+	remaining := lockout.LockedFor(attempt)
+	s.LessOrEqual(remaining, 10*time.Minute)
+	s.Greater(remaining, 10*time.Minute-time.Second)
+
+	// The delay after a failure refuses the email too
+	s.configure("auth.lockout.delay", 2000)
+	other := lockout.Attempt{Email: "delayed@example.com", IPAddress: "203.0.113.31"}
+	delay, err := lockout.Fail(other)
+	s.Require().NoError(err)
+	s.Equal(2*time.Second, delay)
+	s.InDelta(float64(2*time.Second), float64(lockout.LockedFor(other)), float64(100*time.Millisecond))
+}
+
+func (s *LockoutTestSuite) TestAccountLockedIsDispatchedOnce() {
+	s.useLoginTables()
+
+	var key event.Event
+	for e := range facades.Event().GetEvents() {
+		if _, ok := e.(*events.AccountLocked); ok {
+			key = e
+		}
+	}
+	s.Require().NotNil(key)
+	previous := facades.Event().GetEvents()[key]
+	defer facades.Event().Register(map[event.Event][]event.Listener{key: previous})
+	recorder := &recordingListener{}
+	facades.Event().Register(map[event.Event][]event.Listener{key: {recorder}})
+
+	userID := uint(7)
+	s.fail(lockout.Attempt{Email: "locked@example.com", UserID: &userID, IPAddress: "203.0.113.40"}, 5)
+	s.Equal(1, recorder.calls)
+
+	// Unknown emails don't get an email
+	s.fail(lockout.Attempt{Email: "unknown@example.com", IPAddress: "203.0.113.41"}, 3)
+	s.Equal(1, recorder.calls)
+}
+
+// login posts the credentials to the login endpoint
+func (s *LockoutTestSuite) login(email, password string) contractstesting.TestResponse {
+	resp, err := s.Http(s.T()).WithHeader("Content-Type", "application/json").
+		Post("/auth/login", strings.NewReader(`{"email":"`+email+`","password":"`+password+`"}`))
+	s.Require().NoError(err)
+
+	return resp
+}
+
+func (s *LockoutTestSuite) TestUnknownEmailsGetTheSameAnswer() {
+	// Other suites may have used up the auth limit of the client
+	s.configure("rate_limit.enabled", false)
+	s.useLoginTables()
+	s.configure("auth.lockout.max_attempts", 5)
+	password, err := facades.Hash().Make("secret")
+	s.Require().NoError(err)
+	_, err = facades.Orm().Query().Exec(`INSERT INTO users (name, email, password, email_verified_at) VALUES ('Jane', 'jane@example.com', ?, ?)`,
+		password, time.Now())
+	s.Require().NoError(err)
+
+	wrongPassword, err := s.login("jane@example.com", "wrong-password").AssertUnauthorized().Json()
+	s.Require().NoError(err)
+	unknownEmail, err := s.login("nobody@example.com", "wrong-password").AssertUnauthorized().Json()
+	s.Require().NoError(err)
+	s.Equal(wrongPassword, unknownEmail)
+	s.Equal("invalid email or password", unknownEmail["message"])
+}
+
+func (s *LockoutTestSuite) TestFailedLoginsAskToRetryLater() {
+	s.configure("rate_limit.enabled", false)
+	s.useLoginTables()
+	s.configure("auth.lockout.delay", 1500)
+
+	// The answer comes right away and tells when the email may try again
+	resp := s.login("retry@example.com", "wrong-password").AssertUnauthorized()
+	s.Equal("2", resp.Headers().Get("Retry-After"))
+
+	resp = s.login("retry@example.com", "wrong-password").AssertStatus(429)
+	s.NotEmpty(resp.Headers().Get("Retry-After"))
+}
@@ -0,0 +1,95 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/rbac"
+	"evote-be/app/services/tokens"
+	"evote-be/database/migrations"
+	"evote-be/tests"
+)
+
+type MigrationsTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestMigrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(MigrationsTestSuite))
+}
+
+func (s *MigrationsTestSuite) TestConvertUserVerificationColumns() {
+	s.UseSqlite(s.T(), `CREATE TABLE users (id integer PRIMARY KEY, email_verified_at varchar(255),
+		token_expires_at varchar(255), verification_token varchar(255))`,
+		`INSERT INTO users VALUES (1, '2025-01-02 10:00', NULL, NULL), (2, NULL, '2025-01-03 11:00', 'token')`)
+	migration := &migrations.M20250513084107ConvertUserVerificationColumns{}
+
+	s.Require().NoError(migration.Up())
+	s.False(facades.Schema().HasColumn("users", "token_expires_at"))
+
+	var users []struct {
+		ID                    uint
+		EmailVerifiedAt       *time.Time
+		VerificationExpiresAt *time.Time
+		VerificationToken     string
+	}
+	s.Require().NoError(facades.Orm().Query().Raw("SELECT id, email_verified_at, verification_expires_at, verification_token FROM users ORDER BY id").Scan(&users))
+	s.Require().Len(users, 2)
+	s.Require().NotNil(users[0].EmailVerifiedAt)
+	s.Equal("2025-01-02 10:00", users[0].EmailVerifiedAt.In(time.Local).Format("2006-01-02 15:04"))
+	s.Nil(users[1].EmailVerifiedAt)
+	s.NotNil(users[1].VerificationExpiresAt)
+	s.Equal(tokens.Hash("token"), users[1].VerificationToken)
+}
+
+func (s *MigrationsTestSuite) TestConvertUserVerificationColumnsFailsOnInvalidDates() {
+	s.UseSqlite(s.T(), `CREATE TABLE users (id integer PRIMARY KEY, email_verified_at varchar(255),
+		token_expires_at varchar(255), verification_token varchar(255))`,
+		`INSERT INTO users VALUES (1, '2025-01-02 10:00', NULL, NULL), (2, 'yesterday', NULL, NULL)`)
+	migration := &migrations.M20250513084107ConvertUserVerificationColumns{}
+
+	s.ErrorContains(migration.Up(), "email_verified_at of user 2")
+	s.False(facades.Schema().HasColumn("users", "verification_expires_at"))
+
+	var user struct {
+		EmailVerifiedAt string
+	}
+	s.Require().NoError(facades.Orm().Query().Raw("SELECT email_verified_at FROM users WHERE id = 2").Scan(&user))
+	s.Equal("yesterday", user.EmailVerifiedAt)
+}
+
+func (s *MigrationsTestSuite) TestRolesAreSeeded() {
+	s.UseSqlite(s.T(), `CREATE TABLE users (id integer PRIMARY KEY)`)
+
+	s.Require().NoError((&migrations.M20250701092047CreateRolesTables{}).Up())
+	s.Require().NoError((&migrations.M20250729091518CreateLoginAttemptsTable{}).Up())
+	// Running them again doesn't add rows twice
+	s.Require().NoError((&migrations.M20250701092047CreateRolesTables{}).Up())
+	s.Require().NoError((&migrations.M20250729091518CreateLoginAttemptsTable{}).Up())
+
+	permissions := func(role string) []string {
+		var rows []struct {
+			Name string
+		}
+		s.Require().NoError(facades.Orm().Query().Raw(`SELECT permissions.name FROM permissions
+			JOIN role_permissions ON role_permissions.permission_id = permissions.id
+			JOIN roles ON roles.id = role_permissions.role_id
+			WHERE roles.name = ? ORDER BY permissions.name`, role).Scan(&rows))
+
+		names := make([]string, len(rows))
+		for i, row := range rows {
+			names[i] = row.Name
+		}
+		return names
+	}
+
+	s.Len(permissions(rbac.Admin), len(rbac.DefaultPermissions))
+	s.Contains(permissions(rbac.Admin), rbac.LoginAttemptView)
+	s.Equal([]string{rbac.PollCreate, rbac.PollDelete, rbac.PollUpdate, rbac.VoteCast, rbac.WebhookManage}, permissions(rbac.Organizer))
+	s.Equal([]string{rbac.VoteCast}, permissions(rbac.Voter))
+	s.Equal([]string{rbac.PollViewAny}, permissions(rbac.Auditor))
+}
@@ -0,0 +1,241 @@
+package feature
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/oidc"
+	"evote-be/tests"
+)
+
+// mockProvider is a local OpenID Connect provider. Codes are handed out by
+// authorize instead of a login page.
+type mockProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	mu     sync.Mutex
+	grants map[string]url.Values
+	// claims are put into the id token and returned by the userinfo endpoint
+	claims jwt.MapClaims
+	emails []map[string]any
+}
+
+func newMockProvider(clientID string) *mockProvider {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	p := &mockProvider{key: key, clientID: clientID, grants: map[string]url.Values{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"userinfo_endpoint":      p.server.URL + "/userinfo",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kid": "key-1",
+			"kty": "RSA",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(p.claims)
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(p.emails)
+	})
+	p.server = httptest.NewServer(mux)
+
+	return p
+}
+
+// authorize logs the user in at the provider and returns the code the user
+// is redirected back with
+func (p *mockProvider) authorize(authURL string) string {
+	u, _ := url.Parse(authURL)
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	code := base64.RawURLEncoding.EncodeToString(b)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.grants[code] = u.Query()
+	return code
+}
+
+func (p *mockProvider) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	p.mu.Lock()
+	grant, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	// PKCE: the verifier has to match the challenge of the authorization
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || grant.Get("code_challenge_method") != "S256" ||
+		base64.RawURLEncoding.EncodeToString(verifier[:]) != grant.Get("code_challenge") {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   p.clientID,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"nonce": grant.Get("nonce"),
+	}
+	for k, v := range p.claims {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	idToken, _ := token.SignedString(p.key)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+type OIDCTestSuite struct {
+	suite.Suite
+	tests.TestCase
+	mock *mockProvider
+}
+
+func TestOIDCTestSuite(t *testing.T) {
+	suite.Run(t, new(OIDCTestSuite))
+}
+
+func (s *OIDCTestSuite) SetupTest() {
+	s.mock = newMockProvider("evote")
+	s.mock.claims = jwt.MapClaims{"sub": "user-1", "email": "jane@example.com", "email_verified": true, "name": "Jane"}
+}
+
+func (s *OIDCTestSuite) TearDownTest() {
+	s.mock.server.Close()
+}
+
+func (s *OIDCTestSuite) provider() *oidc.Provider {
+	return oidc.NewProvider(oidc.Config{
+		Issuer:      s.mock.server.URL,
+		ClientID:    "evote",
+		RedirectURL: "http://localhost:3000/auth/callback/mock",
+		Scopes:      []string{"openid", "email", "profile"},
+	})
+}
+
+func (s *OIDCTestSuite) TestLogin() {
+	provider := s.provider()
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "verifier-0123456789012345678901234567890123", "nonce")
+	s.Require().NoError(err)
+	query := s.mustQuery(authURL)
+	s.Equal("state", query.Get("state"))
+	s.Equal("evote", query.Get("client_id"))
+	s.Equal("S256", query.Get("code_challenge_method"))
+	s.Equal("nonce", query.Get("nonce"))
+
+	code := s.mock.authorize(authURL)
+	identity, err := provider.Exchange(context.Background(), code, "verifier-0123456789012345678901234567890123", "nonce")
+	s.Require().NoError(err)
+	s.Equal(oidc.Identity{Subject: "user-1", Email: "jane@example.com", EmailVerified: true, Name: "Jane"}, identity)
+}
+
+func (s *OIDCTestSuite) TestLoginRequiresVerifierAndNonce() {
+	provider := s.provider()
+	verifier := "verifier-0123456789012345678901234567890123"
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", verifier, "nonce")
+	s.Require().NoError(err)
+
+	// A stolen code is useless without the verifier
+	_, err = provider.Exchange(context.Background(), s.mock.authorize(authURL), "another-verifier-0123456789012345678901234", "nonce")
+	s.Error(err)
+
+	// The id token has to be issued for the login that was started
+	_, err = provider.Exchange(context.Background(), s.mock.authorize(authURL), verifier, "another-nonce")
+	s.ErrorIs(err, oidc.ErrInvalidIDToken)
+
+	// and for this client
+	other := oidc.NewProvider(oidc.Config{Issuer: s.mock.server.URL, ClientID: "other"})
+	authURL, err = other.AuthCodeURL(context.Background(), "state", verifier, "nonce")
+	s.Require().NoError(err)
+	_, err = other.Exchange(context.Background(), s.mock.authorize(authURL), verifier, "nonce")
+	s.ErrorIs(err, oidc.ErrInvalidIDToken)
+}
+
+func (s *OIDCTestSuite) TestUnverifiedEmail() {
+	s.mock.claims["email_verified"] = "false"
+	provider := s.provider()
+	verifier := "verifier-0123456789012345678901234567890123"
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", verifier, "nonce")
+	s.Require().NoError(err)
+	identity, err := provider.Exchange(context.Background(), s.mock.authorize(authURL), verifier, "nonce")
+	s.Require().NoError(err)
+	s.False(identity.EmailVerified)
+}
+
+func (s *OIDCTestSuite) TestOAuthProviderWithEmails() {
+	s.mock.claims = jwt.MapClaims{"id": float64(42), "login": "jane", "email": "public@example.com"}
+	s.mock.emails = []map[string]any{
+		{"email": "public@example.com", "primary": false, "verified": false},
+		{"email": "jane@example.com", "primary": true, "verified": true},
+	}
+	provider := oidc.NewProvider(oidc.Config{
+		ClientID:    "evote",
+		AuthURL:     s.mock.server.URL + "/authorize",
+		TokenURL:    s.mock.server.URL + "/token",
+		UserInfoURL: s.mock.server.URL + "/userinfo",
+		EmailsURL:   s.mock.server.URL + "/user/emails",
+	})
+	verifier := "verifier-0123456789012345678901234567890123"
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", verifier, "")
+	s.Require().NoError(err)
+	s.Empty(s.mustQuery(authURL).Get("nonce"))
+
+	identity, err := provider.Exchange(context.Background(), s.mock.authorize(authURL), verifier, "")
+	s.Require().NoError(err)
+	s.Equal(oidc.Identity{Subject: "42", Email: "jane@example.com", EmailVerified: true, Name: "jane"}, identity)
+}
+
+func (s *OIDCTestSuite) mustQuery(rawURL string) url.Values {
+	u, err := url.Parse(rawURL)
+	s.Require().NoError(err)
+	return u.Query()
+}
@@ -0,0 +1,94 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/organizations"
+	"evote-be/tests"
+)
+
+type OrganizationsTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestOrganizationsTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationsTestSuite))
+}
+
+func (s *OrganizationsTestSuite) TestSlugify() {
+	s.Equal("human-resources", organizations.Slugify("Human Resources"))
+	s.Equal("r-d-2025", organizations.Slugify("  R&D / 2025! "))
+	s.Equal("", organizations.Slugify("!!!"))
+}
+
+func (s *OrganizationsTestSuite) TestCanManage() {
+	s.True(models.OrganizationOwner.CanManage())
+	s.True(models.OrganizationAdmin.CanManage())
+	s.False(models.OrganizationMember.CanManage())
+	s.False(models.OrganizationRole("").CanManage())
+}
+
+// organizationTables hold two organizations with a poll in the first. The
+// owner of the poll is a member of both, the outsider of none.
+var organizationTables = []string{
+	`CREATE TABLE organizations (id integer PRIMARY KEY AUTOINCREMENT, name text, slug text, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE organization_members (id integer PRIMARY KEY AUTOINCREMENT, organization_id integer, user_id integer, role text,
+		created_at datetime, updated_at datetime)`,
+	`CREATE TABLE polls (id integer PRIMARY KEY AUTOINCREMENT, title text, description text, status text, start_date datetime,
+		end_date datetime, code text, user_id integer, organization_id integer, created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`INSERT INTO organizations (id, name, slug) VALUES (1, 'Sales', 'sales'), (2, 'Support', 'support')`,
+	`INSERT INTO organization_members (organization_id, user_id, role) VALUES (1, 525251, 'member'), (2, 525251, 'member')`,
+	`INSERT INTO polls (id, title, status, start_date, end_date, user_id, organization_id)
+		VALUES (1, 'Team lunch', 'draft', '2025-01-01 00:00:00', '2025-01-02 00:00:00', 525251, 1)`,
+}
+
+// showPoll requests the poll with an API key of the user in the organization,
+// none for the personal workspace
+func (s *OrganizationsTestSuite) showPoll(userID uint, organization string) contractstesting.TestResponse {
+	request := s.Http(s.T()).WithHeader("Authorization", "Bearer "+cacheApiKey(s.Require(), userID, "polls:read", time.Now().Add(time.Hour)))
+	if organization != "" {
+		request = request.WithHeader("X-Organization", organization)
+	}
+	resp, err := request.Get("/polls/1")
+	s.Require().NoError(err)
+
+	return resp
+}
+
+func (s *OrganizationsTestSuite) TestPollVisibleInItsOrganization() {
+	s.UseSqlite(s.T(), organizationTables...)
+
+	body, err := s.showPoll(525251, "sales").AssertOk().Json()
+	s.Require().NoError(err)
+	s.Equal("Team lunch", body["data"].(map[string]any)["title"])
+	s.showPoll(525251, "1").AssertOk()
+}
+
+func (s *OrganizationsTestSuite) TestPollNotFoundInOtherOrganization() {
+	s.UseSqlite(s.T(), organizationTables...)
+
+	s.showPoll(525251, "support").AssertNotFound()
+}
+
+func (s *OrganizationsTestSuite) TestPollNotFoundInPersonalWorkspace() {
+	s.UseSqlite(s.T(), organizationTables...)
+
+	s.showPoll(525251, "").AssertNotFound()
+}
+
+func (s *OrganizationsTestSuite) TestNonMemberForbidden() {
+	s.UseSqlite(s.T(), organizationTables...)
+
+	resp := s.showPoll(525253, "sales").AssertForbidden()
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal(organizations.ErrNotMember.Error(), body["errors"])
+
+	s.showPoll(525253, "unknown").AssertNotFound()
+}
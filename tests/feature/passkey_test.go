@@ -0,0 +1,214 @@
+package feature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/ugorji/go/codec"
+
+	"evote-be/app/services/passkey"
+	"evote-be/tests"
+)
+
+const (
+	passkeyOrigin    = "https://evote.test"
+	passkeyChallenge = "c2VydmVyLWNoYWxsZW5nZQ"
+)
+
+// softwareAuthenticator creates and uses a passkey like a browser and an
+// authenticator would
+type softwareAuthenticator struct {
+	rpID      string
+	id        []byte
+	signer    crypto.Signer
+	signCount uint32
+	verified  bool
+}
+
+func newSoftwareAuthenticator(rpID string, signer crypto.Signer) *softwareAuthenticator {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return &softwareAuthenticator{rpID: rpID, id: id, signer: signer, verified: true}
+}
+
+func (a *softwareAuthenticator) publicKey() []byte {
+	var key map[int]any
+	switch pub := a.signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		key = map[int]any{1: 2, 3: passkey.AlgES256, -1: 1, -2: pub.X.FillBytes(make([]byte, 32)), -3: pub.Y.FillBytes(make([]byte, 32))}
+	case ed25519.PublicKey:
+		key = map[int]any{1: 1, 3: passkey.AlgEdDSA, -1: 6, -2: []byte(pub)}
+	}
+	return cborEncode(key)
+}
+
+func (a *softwareAuthenticator) authData(attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+
+	flags := byte(0x01)
+	if a.verified {
+		flags |= 0x04
+	}
+	if attested {
+		flags |= 0x40
+	}
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.id)))
+		data = append(data, a.id...)
+		data = append(data, a.publicKey()...)
+	}
+	return data
+}
+
+func (a *softwareAuthenticator) create(origin, challenge string) passkey.AttestationResponse {
+	object := cborEncode(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": a.authData(true),
+	})
+
+	return passkey.AttestationResponse{
+		ClientDataJSON:    clientDataJSON("webauthn.create", origin, challenge),
+		AttestationObject: passkey.Encode(object),
+	}
+}
+
+func (a *softwareAuthenticator) get(origin, challenge string) passkey.AssertionResponse {
+	a.signCount++
+	clientData := clientDataJSON("webauthn.get", origin, challenge)
+	raw, _ := passkey.Decode(clientData)
+	clientDataHash := sha256.Sum256(raw)
+
+	authData := a.authData(false)
+	signed := append(append([]byte{}, authData...), clientDataHash[:]...)
+
+	var signature []byte
+	switch a.signer.(type) {
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(signed)
+		signature, _ = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case ed25519.PrivateKey:
+		signature, _ = a.signer.Sign(rand.Reader, signed, crypto.Hash(0))
+	}
+
+	return passkey.AssertionResponse{
+		ClientDataJSON:    clientData,
+		AuthenticatorData: passkey.Encode(authData),
+		Signature:         passkey.Encode(signature),
+	}
+}
+
+func clientDataJSON(ceremony, origin, challenge string) string {
+	raw, _ := json.Marshal(passkey.ClientData{Type: ceremony, Challenge: challenge, Origin: origin})
+	return passkey.Encode(raw)
+}
+
+func cborEncode(v any) []byte {
+	var b []byte
+	_ = codec.NewEncoderBytes(&b, &codec.CborHandle{}).Encode(v)
+	return b
+}
+
+type PasskeyTestSuite struct {
+	suite.Suite
+	tests.TestCase
+	rp passkey.RelyingParty
+}
+
+func TestPasskeyTestSuite(t *testing.T) {
+	suite.Run(t, new(PasskeyTestSuite))
+}
+
+func (s *PasskeyTestSuite) SetupTest() {
+	s.rp = passkey.RelyingParty{ID: "evote.test", Origins: []string{passkeyOrigin}}
+}
+
+func (s *PasskeyTestSuite) TestRegisterAndLogin() {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	s.Require().NoError(err)
+
+	for _, signer := range []crypto.Signer{ecKey, edKey} {
+		authenticator := newSoftwareAuthenticator(s.rp.ID, signer)
+
+		credential, err := s.rp.VerifyRegistration(passkeyChallenge, authenticator.create(passkeyOrigin, passkeyChallenge))
+		s.Require().NoError(err)
+		s.Equal(authenticator.id, credential.ID)
+		s.Equal(uint32(0), credential.SignCount)
+
+		assertion, err := s.rp.VerifyAssertion(passkeyChallenge, credential.PublicKey, credential.SignCount, authenticator.get(passkeyOrigin, passkeyChallenge))
+		s.Require().NoError(err)
+		s.Equal(uint32(1), assertion.SignCount)
+		s.True(assertion.UserVerified)
+	}
+}
+
+func (s *PasskeyTestSuite) TestRegistrationIsBoundToChallengeOriginAndRelyingParty() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	authenticator := newSoftwareAuthenticator(s.rp.ID, key)
+
+	_, err = s.rp.VerifyRegistration("b3RoZXI", authenticator.create(passkeyOrigin, passkeyChallenge))
+	s.ErrorIs(err, passkey.ErrChallengeMismatch)
+
+	_, err = s.rp.VerifyRegistration(passkeyChallenge, authenticator.create("https://evil.test", passkeyChallenge))
+	s.ErrorIs(err, passkey.ErrOriginNotAllowed)
+
+	other := newSoftwareAuthenticator("evil.test", key)
+	_, err = s.rp.VerifyRegistration(passkeyChallenge, other.create(passkeyOrigin, passkeyChallenge))
+	s.ErrorIs(err, passkey.ErrRelyingPartyMismatch)
+
+	// An assertion is not accepted as a registration
+	resp := authenticator.get(passkeyOrigin, passkeyChallenge)
+	_, err = s.rp.VerifyRegistration(passkeyChallenge, passkey.AttestationResponse{ClientDataJSON: resp.ClientDataJSON})
+	s.ErrorIs(err, passkey.ErrInvalidResponse)
+}
+
+func (s *PasskeyTestSuite) TestAssertionRejectsOtherKeysAndClones() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	authenticator := newSoftwareAuthenticator(s.rp.ID, key)
+	credential, err := s.rp.VerifyRegistration(passkeyChallenge, authenticator.create(passkeyOrigin, passkeyChallenge))
+	s.Require().NoError(err)
+
+	// Signed by another key
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	other := newSoftwareAuthenticator(s.rp.ID, otherKey)
+	_, err = s.rp.VerifyAssertion(passkeyChallenge, credential.PublicKey, 0, other.get(passkeyOrigin, passkeyChallenge))
+	s.ErrorIs(err, passkey.ErrInvalidSignature)
+
+	// Tampered authenticator data
+	resp := authenticator.get(passkeyOrigin, passkeyChallenge)
+	authData, err := passkey.Decode(resp.AuthenticatorData)
+	s.Require().NoError(err)
+	authData[36]++
+	resp.AuthenticatorData = passkey.Encode(authData)
+	_, err = s.rp.VerifyAssertion(passkeyChallenge, credential.PublicKey, 0, resp)
+	s.ErrorIs(err, passkey.ErrInvalidSignature)
+
+	// Sign count not above the stored one
+	resp = authenticator.get(passkeyOrigin, passkeyChallenge)
+	_, err = s.rp.VerifyAssertion(passkeyChallenge, credential.PublicKey, authenticator.signCount, resp)
+	s.ErrorIs(err, passkey.ErrClonedAuthenticator)
+
+	// Without user verification
+	authenticator.verified = false
+	assertion, err := s.rp.VerifyAssertion(passkeyChallenge, credential.PublicKey, 0, authenticator.get(passkeyOrigin, passkeyChallenge))
+	s.Require().NoError(err)
+	s.False(assertion.UserVerified)
+}
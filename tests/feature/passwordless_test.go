@@ -0,0 +1,159 @@
+package feature
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/passwordless"
+	"evote-be/app/services/tokens"
+	"evote-be/tests"
+)
+
+type PasswordlessTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestPasswordlessTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordlessTestSuite))
+}
+
+func (s *PasswordlessTestSuite) TestGenerateCode() {
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		code, err := passwordless.GenerateCode()
+		s.Require().NoError(err)
+		s.Regexp(regexp.MustCompile(`^[0-9]{6}$`), code)
+		seen[code] = true
+	}
+	s.Greater(len(seen), 90)
+}
+
+func (s *PasswordlessTestSuite) TestHashCode() {
+	s.Equal(passwordless.HashCode(1, "123456"), passwordless.HashCode(1, "123456"))
+	s.NotEqual(passwordless.HashCode(1, "123456"), passwordless.HashCode(2, "123456"))
+	s.NotEqual(passwordless.HashCode(1, "123456"), passwordless.HashCode(1, "123457"))
+}
+
+// passwordlessTables hold Jane, whose email is not verified yet, and John, who
+// has two-factor authentication, with the tables a login is stored in
+var passwordlessTables = append([]string{
+	`CREATE TABLE users (id integer PRIMARY KEY AUTOINCREMENT, name text, email text, password text, avatar text, email_verified_at datetime,
+		verification_token text, verification_expires_at datetime, verification_sent_at datetime, tokens_valid_after datetime,
+		two_factor_secret text, two_factor_confirmed_at datetime, two_factor_last_step integer, banned_at datetime, ban_reason text,
+		created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`INSERT INTO users (id, name, email) VALUES (1, 'Jane', 'jane@example.com')`,
+	`INSERT INTO users (id, name, email, two_factor_confirmed_at) VALUES (2, 'John', 'john@example.com', '2025-01-01 00:00:00')`,
+	`CREATE TABLE login_tokens (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, token text, code text, attempts integer DEFAULT 0,
+		expires_at datetime, used_at datetime, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE login_attempts (id integer PRIMARY KEY AUTOINCREMENT, email text, user_id integer, ip_address text, user_agent text,
+		successful numeric, reason text, created_at datetime, updated_at datetime)`,
+}, tokenTables[2:]...)
+
+func (s *PasswordlessTestSuite) SetupTest() {
+	s.UseSqlite(s.T(), passwordlessTables...)
+
+	// Other suites may have used up the auth limit of the client
+	attempts := facades.Config().Get("rate_limit.limiters.auth.attempts")
+	facades.Config().Add("rate_limit.limiters.auth.attempts", 0)
+	s.T().Cleanup(func() { facades.Config().Add("rate_limit.limiters.auth.attempts", attempts) })
+}
+
+// createLogin stores the link token and code of a login sent to the user
+func (s *PasswordlessTestSuite) createLogin(userID uint, token, code string, expiresAt time.Time) {
+	s.Require().NoError(facades.Orm().Query().Create(&models.LoginTokens{
+		UserID:    userID,
+		Token:     tokens.Hash(token),
+		Code:      passwordless.HashCode(userID, code),
+		ExpiresAt: expiresAt,
+	}))
+}
+
+func (s *PasswordlessTestSuite) post(uri, body string) contractstesting.TestResponse {
+	resp, err := s.Http(s.T()).WithHeader("Content-Type", "application/json").Post(uri, strings.NewReader(body))
+	s.Require().NoError(err)
+
+	return resp
+}
+
+// data returns the data of a successful response
+func (s *PasswordlessTestSuite) data(resp contractstesting.TestResponse) map[string]any {
+	body, err := resp.AssertOk().Json()
+	s.Require().NoError(err)
+	data, ok := body["data"].(map[string]any)
+	s.Require().True(ok)
+
+	return data
+}
+
+func (s *PasswordlessTestSuite) TestLinkWorksOnce() {
+	s.createLogin(1, "link-token", "123456", time.Now().Add(time.Minute))
+
+	data := s.data(s.post("/auth/passwordless/verify", `{"token":"link-token"}`))
+	s.Equal("jane@example.com", data["email"])
+	s.NotEmpty(data["token"])
+
+	s.post("/auth/passwordless/verify", `{"token":"link-token"}`).AssertUnauthorized()
+	// The code was sent with the link and is used up too
+	s.post("/auth/passwordless/verify", `{"email":"jane@example.com","code":"123456"}`).AssertUnauthorized()
+
+	// Jane proved to own the email
+	var user models.User
+	s.Require().NoError(facades.Orm().Query().Where("id = ?", 1).First(&user))
+	s.NotNil(user.EmailVerifiedAt)
+}
+
+func (s *PasswordlessTestSuite) TestCodeWorksOnce() {
+	s.createLogin(1, "link-token", "123456", time.Now().Add(time.Minute))
+
+	s.post("/auth/passwordless/verify", `{"email":"jane@example.com"}`).AssertBadRequest()
+	s.post("/auth/passwordless/verify", `{"email":"jane@example.com","code":"654321"}`).AssertUnauthorized()
+	s.data(s.post("/auth/passwordless/verify", `{"email":"jane@example.com","code":"123456"}`))
+	s.post("/auth/passwordless/verify", `{"email":"jane@example.com","code":"123456"}`).AssertUnauthorized()
+	s.post("/auth/passwordless/verify", `{"token":"link-token"}`).AssertUnauthorized()
+}
+
+func (s *PasswordlessTestSuite) TestExpiredLoginsAreRefused() {
+	s.createLogin(1, "link-token", "123456", time.Now().Add(-time.Second))
+
+	s.post("/auth/passwordless/verify", `{"token":"link-token"}`).AssertUnauthorized()
+	s.post("/auth/passwordless/verify", `{"email":"jane@example.com","code":"123456"}`).AssertUnauthorized()
+}
+
+func (s *PasswordlessTestSuite) TestLoginMatchesThePasswordLogin() {
+	password, err := facades.Hash().Make("secret-password")
+	s.Require().NoError(err)
+	_, err = facades.Orm().Query().Exec(`UPDATE users SET password = ?, email_verified_at = ?`, password, time.Now())
+	s.Require().NoError(err)
+	s.createLogin(1, "jane-token", "123456", time.Now().Add(time.Minute))
+	s.createLogin(2, "john-token", "123456", time.Now().Add(time.Minute))
+
+	keys := func(data map[string]any) []string {
+		var keys []string
+		for key := range data {
+			keys = append(keys, key)
+		}
+
+		return keys
+	}
+
+	// Tokens for Jane
+	withPassword := s.data(s.post("/auth/login", `{"email":"jane@example.com","password":"secret-password"}`))
+	withLink := s.data(s.post("/auth/passwordless/verify", `{"token":"jane-token"}`))
+	s.ElementsMatch(keys(withPassword), keys(withLink))
+	s.Equal(withPassword["id"], withLink["id"])
+
+	// A challenge for John, who has two-factor authentication
+	withPassword = s.data(s.post("/auth/login", `{"email":"john@example.com","password":"secret-password"}`))
+	withLink = s.data(s.post("/auth/passwordless/verify", `{"token":"john-token"}`))
+	s.ElementsMatch(keys(withPassword), keys(withLink))
+	s.Equal(true, withLink["two_factor_required"])
+	s.Nil(withLink["token"])
+}
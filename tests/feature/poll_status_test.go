@@ -0,0 +1,49 @@
+package feature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/tests"
+)
+
+type PollStatusTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestPollStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(PollStatusTestSuite))
+}
+
+func (s *PollStatusTestSuite) TestCanTransitionTo() {
+	s.True(models.Draft.CanTransitionTo(models.Scheduled))
+	s.True(models.Draft.CanTransitionTo(models.Active))
+	s.True(models.Active.CanTransitionTo(models.Paused))
+	s.True(models.Paused.CanTransitionTo(models.Active))
+	s.True(models.Done.CanTransitionTo(models.Archived))
+
+	// Finished polls cannot be reopened
+	s.False(models.Done.CanTransitionTo(models.Active))
+	s.False(models.Cancelled.CanTransitionTo(models.Scheduled))
+	s.False(models.Archived.CanTransitionTo(models.Done))
+	s.False(models.Draft.CanTransitionTo(models.Paused))
+}
+
+func (s *PollStatusTestSuite) TestIsFinal() {
+	s.True(models.Done.IsFinal())
+	s.True(models.Cancelled.IsFinal())
+	s.True(models.Archived.IsFinal())
+	s.False(models.Paused.IsFinal())
+	s.False(models.Draft.IsFinal())
+}
+
+func (s *PollStatusTestSuite) TestLocksContent() {
+	s.True(models.Active.LocksContent())
+	s.True(models.Paused.LocksContent())
+	s.True(models.Done.LocksContent())
+	s.False(models.Draft.LocksContent())
+	s.False(models.Scheduled.LocksContent())
+}
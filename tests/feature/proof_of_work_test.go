@@ -0,0 +1,97 @@
+package feature
+
+import (
+	"net/http"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/http/middleware"
+	"evote-be/app/services/pow"
+	"evote-be/tests"
+)
+
+type ProofOfWorkTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestProofOfWorkTestSuite(t *testing.T) {
+	suite.Run(t, new(ProofOfWorkTestSuite))
+}
+
+func (s *ProofOfWorkTestSuite) SetupSuite() {
+	facades.Route().Middleware(middleware.ProofOfWork()).Get("/testing/pow", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Success().Json(contractshttp.Json{"ok": true})
+	})
+}
+
+func (s *ProofOfWorkTestSuite) SetupTest() {
+	facades.Config().Add("proof_of_work.enabled", true)
+	facades.Config().Add("proof_of_work.difficulty", 8)
+	facades.Config().Add("proof_of_work.max_difficulty", 10)
+	facades.Config().Add("proof_of_work.step", 30)
+}
+
+func (s *ProofOfWorkTestSuite) TearDownTest() {
+	facades.Config().Add("proof_of_work.enabled", false)
+	facades.Config().Add("proof_of_work.difficulty", 16)
+	facades.Config().Add("proof_of_work.max_difficulty", 24)
+}
+
+func (s *ProofOfWorkTestSuite) TestLeadingZeroBits() {
+	s.Equal(0, pow.LeadingZeroBits([]byte{0x80, 0x00}))
+	s.Equal(4, pow.LeadingZeroBits([]byte{0x0f, 0xff}))
+	s.Equal(11, pow.LeadingZeroBits([]byte{0x00, 0x10}))
+	s.Equal(16, pow.LeadingZeroBits([]byte{0x00, 0x00}))
+}
+
+func (s *ProofOfWorkTestSuite) TestSolve() {
+	nonce := pow.Solve("challenge", 8)
+
+	s.True(pow.Solves("challenge", nonce, 8))
+	s.False(pow.Solves("other", nonce, 30))
+}
+
+func (s *ProofOfWorkTestSuite) TestDifficultyAdaptsToRequestRate() {
+	s.Equal(8, pow.Difficulty(1))
+	s.Equal(9, pow.Difficulty(30))
+	s.Equal(10, pow.Difficulty(10000))
+}
+
+func (s *ProofOfWorkTestSuite) TestPublicPollsRequireProofOfWork() {
+	resp, err := s.Http(s.T()).Get("/polls/public?code=unknown")
+	s.Require().NoError(err)
+	resp.AssertStatus(http.StatusPreconditionRequired)
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("PROOF_OF_WORK_REQUIRED", body["errors"])
+}
+
+func (s *ProofOfWorkTestSuite) TestChallengeIsSingleUse() {
+	resp, err := s.Http(s.T()).Get("/pow/challenge")
+	s.Require().NoError(err)
+	resp.AssertOk()
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	data := body["data"].(map[string]any)
+	challenge := data["challenge"].(string)
+	nonce := pow.Solve(challenge, int(data["difficulty"].(float64)))
+
+	headers := map[string]string{"X-Pow-Challenge": challenge, "X-Pow-Nonce": nonce}
+	resp, err = s.Http(s.T()).WithHeaders(headers).Get("/testing/pow")
+	s.Require().NoError(err)
+	resp.AssertOk()
+
+	resp, err = s.Http(s.T()).WithHeaders(headers).Get("/testing/pow")
+	s.Require().NoError(err)
+	resp.AssertStatus(http.StatusPreconditionRequired)
+
+	body, err = resp.Json()
+	s.Require().NoError(err)
+	s.Equal("PROOF_OF_WORK_INVALID", body["errors"])
+}
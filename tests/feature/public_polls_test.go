@@ -0,0 +1,53 @@
+package feature
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/tests"
+)
+
+type PublicPollsTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestPublicPollsTestSuite(t *testing.T) {
+	suite.Run(t, new(PublicPollsTestSuite))
+}
+
+func (s *PublicPollsTestSuite) TestServedFromCache() {
+	code := "cached-poll"
+	data, err := json.Marshal(models.PublicPollsResponse{
+		ID:        1,
+		Title:     "Team lunch",
+		Status:    models.Active,
+		StartDate: time.Now().Add(-time.Hour),
+		EndDate:   time.Now().Add(time.Hour),
+		Code:      &code,
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(facades.Cache().Put(models.PublicPollCacheKey(code), string(data), time.Minute))
+	defer facades.Cache().Forget(models.PublicPollCacheKey(code))
+
+	resp, err := s.Http(s.T()).Get("/polls/public?code=" + code)
+	s.Require().NoError(err)
+	body, err := resp.AssertOk().Json()
+	s.Require().NoError(err)
+	s.Equal("Team lunch", body["data"].(map[string]any)["title"])
+}
+
+func (s *PublicPollsTestSuite) TestInvalidCacheEntryForgotten() {
+	code := "invalid-poll"
+	s.Require().NoError(facades.Cache().Put(models.PublicPollCacheKey(code), "{", time.Minute))
+
+	resp, err := s.Http(s.T()).Get("/polls/public?code=" + code)
+	s.Require().NoError(err)
+	resp.AssertInternalServerError()
+	s.False(facades.Cache().Has(models.PublicPollCacheKey(code)))
+}
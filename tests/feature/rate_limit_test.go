@@ -0,0 +1,123 @@
+package feature
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/tests"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) TestAuthLimiterPerIP() {
+	facades.Config().Add("rate_limit.limiters.auth.attempts", 2)
+	defer facades.Config().Add("rate_limit.limiters.auth.attempts", 20)
+
+	for i := 0; i < 2; i++ {
+		resp, err := s.Http(s.T()).Get("/auth/oidc/unknown/redirect")
+		s.Require().NoError(err)
+		resp.AssertNotFound()
+	}
+
+	resp, err := s.Http(s.T()).Get("/auth/oidc/unknown/redirect")
+	s.Require().NoError(err)
+	resp.AssertTooManyRequests()
+	s.NotEmpty(resp.Headers().Get("Retry-After"))
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("Too many requests", body["message"])
+}
+
+// configure changes a config value until the test finishes
+func (s *RateLimitTestSuite) configure(key string, value any) {
+	previous := facades.Config().Get(key)
+	facades.Config().Add(key, value)
+	s.T().Cleanup(func() { facades.Config().Add(key, previous) })
+}
+
+// assertThrottled checks that a request was refused by a limiter
+func (s *RateLimitTestSuite) assertThrottled(resp contractstesting.TestResponse) {
+	resp.AssertTooManyRequests()
+	s.NotEmpty(resp.Headers().Get("Retry-After"))
+}
+
+// assertPassed checks that a request got past the limiters, the handler may
+// still refuse it
+func (s *RateLimitTestSuite) assertPassed(resp contractstesting.TestResponse) {
+	s.NotEmpty(resp.Headers().Get("X-RateLimit-Remaining"))
+	s.Empty(resp.Headers().Get("Retry-After"))
+}
+
+func (s *RateLimitTestSuite) TestVotesLimiterPerUser() {
+	s.configure("rate_limit.limiters.votes.attempts", 2)
+
+	vote := func(userID uint) contractstesting.TestResponse {
+		resp, err := s.Http(s.T()).WithHeaders(map[string]string{
+			"Authorization": "Bearer " + cacheApiKey(s.Require(), userID, "votes:write", time.Now().Add(time.Hour)),
+			"Content-Type":  "application/json",
+		}).Post("/votes/create", strings.NewReader(`{"poll_id":1,"option_id":1}`))
+		s.Require().NoError(err)
+
+		return resp
+	}
+
+	s.assertPassed(vote(901))
+	s.assertPassed(vote(901))
+	s.assertThrottled(vote(901))
+
+	// Other users vote from the same client
+	s.assertPassed(vote(902))
+}
+
+func (s *RateLimitTestSuite) TestPublicPollsLimiterPerCode() {
+	s.configure("rate_limit.limiters.public_polls.attempts", 2)
+
+	lookup := func(code string) contractstesting.TestResponse {
+		resp, err := s.Http(s.T()).Get("/polls/public?code=" + code)
+		s.Require().NoError(err)
+
+		return resp
+	}
+
+	s.assertPassed(lookup("throttled-poll"))
+	s.assertPassed(lookup("throttled-poll"))
+	s.assertThrottled(lookup("throttled-poll"))
+
+	// Other polls are looked up from the same client
+	s.assertPassed(lookup("other-poll"))
+}
+
+func (s *RateLimitTestSuite) TestPasswordlessLimiterPerEmail() {
+	// Other suites may have used up the auth limit of the client
+	s.configure("rate_limit.limiters.auth.attempts", 0)
+	s.configure("auth.passwordless.per_email", 2)
+
+	send := func(email string) contractstesting.TestResponse {
+		resp, err := s.Http(s.T()).WithHeader("Content-Type", "application/json").
+			Post("/auth/passwordless/send", strings.NewReader(`{"email":"`+email+`"}`))
+		s.Require().NoError(err)
+
+		return resp
+	}
+
+	s.assertPassed(send("throttled@example.com"))
+	s.assertPassed(send("throttled@example.com"))
+
+	// Addresses are counted regardless of case and spaces
+	s.assertThrottled(send(" Throttled@Example.com"))
+
+	s.assertPassed(send("other@example.com"))
+}
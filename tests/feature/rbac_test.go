@@ -0,0 +1,102 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/rbac"
+	"evote-be/tests"
+)
+
+type RBACTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestRBACTestSuite(t *testing.T) {
+	suite.Run(t, new(RBACTestSuite))
+}
+
+func (s *RBACTestSuite) TestDefaultRolesUseKnownPermissions() {
+	names := map[string]bool{}
+	for _, role := range rbac.DefaultRoles {
+		s.False(names[role.Name], role.Name)
+		names[role.Name] = true
+
+		for _, permission := range role.Permissions {
+			s.Contains(rbac.DefaultPermissions, permission, role.Name)
+		}
+	}
+
+	s.True(names[rbac.Admin])
+	s.True(names[rbac.Organizer])
+	s.True(names[rbac.Voter])
+	s.True(names[rbac.Auditor])
+}
+
+func (s *RBACTestSuite) TestAdminHasEveryPermission() {
+	for _, role := range rbac.DefaultRoles {
+		if role.Name != rbac.Admin {
+			continue
+		}
+		s.Len(role.Permissions, len(rbac.DefaultPermissions))
+	}
+}
+
+func (s *RBACTestSuite) TestAuditorIsReadOnly() {
+	for _, role := range rbac.DefaultRoles {
+		if role.Name != rbac.Auditor {
+			continue
+		}
+		s.Equal([]string{rbac.PollViewAny}, role.Permissions)
+	}
+}
+
+// rbacTables hold the auditor, voter and organizer roles, user 7 is an auditor
+var rbacTables = []string{
+	`CREATE TABLE roles (id integer PRIMARY KEY AUTOINCREMENT, name text, description text, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE permissions (id integer PRIMARY KEY AUTOINCREMENT, name text, description text, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE role_permissions (role_id integer, permission_id integer)`,
+	`CREATE TABLE user_roles (user_id integer, role_id integer)`,
+	`INSERT INTO roles (id, name) VALUES (1, 'auditor'), (2, 'voter'), (3, 'organizer')`,
+	`INSERT INTO permissions (id, name) VALUES (1, 'poll.view.any'), (2, 'poll.delete'), (3, 'vote.cast'), (4, 'poll.create')`,
+	`INSERT INTO role_permissions (role_id, permission_id) VALUES (1, 1), (2, 3), (3, 3), (3, 4)`,
+	`INSERT INTO user_roles (user_id, role_id) VALUES (7, 1)`,
+}
+
+func (s *RBACTestSuite) TestPermissionsCachedAsJSON() {
+	s.UseSqlite(s.T(), rbacTables...)
+	rbac.Forget(7)
+	defer rbac.Forget(7)
+
+	permissions, err := rbac.Permissions(7)
+	s.Require().NoError(err)
+	s.Equal(map[string]bool{rbac.PollViewAny: true}, permissions)
+	s.IsType("", facades.Cache().Get("auth:permissions:7"))
+
+	// An entry that can't be decoded is read again from the database
+	s.Require().NoError(facades.Cache().Put("auth:permissions:7", map[string]bool{rbac.PollDelete: true}, time.Minute))
+	s.False(rbac.Can(7, rbac.PollDelete))
+	s.True(rbac.Can(7, rbac.PollViewAny))
+}
+
+func (s *RBACTestSuite) TestUsersWithoutRolesAreVoters() {
+	s.UseSqlite(s.T(), rbacTables...)
+	rbac.Forget(8)
+	defer rbac.Forget(8)
+
+	permissions, err := rbac.Permissions(8)
+	s.Require().NoError(err)
+	s.Equal(map[string]bool{rbac.VoteCast: true}, permissions)
+	s.False(rbac.Can(8, rbac.PollCreate))
+
+	// Deployments may let every account organize polls
+	role := facades.Config().GetString("auth.default_role")
+	defer facades.Config().Add("auth.default_role", role)
+	facades.Config().Add("auth.default_role", rbac.Organizer)
+	rbac.Forget(8)
+	s.True(rbac.Can(8, rbac.PollCreate))
+}
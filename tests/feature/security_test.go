@@ -0,0 +1,103 @@
+package feature
+
+import (
+	"bytes"
+	"html/template"
+	"net/http"
+	"testing"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/http/middleware"
+	"evote-be/tests"
+)
+
+type SecurityTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestSecurityTestSuite(t *testing.T) {
+	suite.Run(t, new(SecurityTestSuite))
+}
+
+func (s *SecurityTestSuite) SetupSuite() {
+	facades.Route().Middleware(middleware.VerifyCsrfToken()).Post("/testing/csrf", func(ctx contractshttp.Context) contractshttp.Response {
+		return ctx.Response().Success().Json(contractshttp.Json{"ok": true})
+	})
+}
+
+func (s *SecurityTestSuite) TestSecurityHeaders() {
+	resp, err := s.Http(s.T()).Get("/pow/challenge")
+	s.Require().NoError(err)
+	resp.AssertOk()
+
+	headers := resp.Headers()
+	s.Equal("DENY", headers.Get("X-Frame-Options"))
+	s.Equal("nosniff", headers.Get("X-Content-Type-Options"))
+	s.Equal("strict-origin-when-cross-origin", headers.Get("Referrer-Policy"))
+	s.Contains(headers.Get("Content-Security-Policy"), "frame-ancestors 'none'")
+	s.Equal("max-age=31536000; includeSubDomains", headers.Get("Strict-Transport-Security"))
+}
+
+func (s *SecurityTestSuite) TestNoCorsWithoutAllowedOrigins() {
+	resp, err := s.Http(s.T()).WithHeader("Origin", "https://evil.example").Get("/pow/challenge")
+	s.Require().NoError(err)
+
+	s.Empty(resp.Headers().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *SecurityTestSuite) TestCsrfTokenRequired() {
+	resp, err := s.Http(s.T()).Post("/testing/csrf", nil)
+	s.Require().NoError(err)
+	resp.AssertForbidden()
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("CSRF_TOKEN_MISMATCH", body["errors"])
+}
+
+func (s *SecurityTestSuite) TestCsrfTokenMatchingCookie() {
+	resp, err := s.Http(s.T()).
+		WithCookie("XSRF-TOKEN", "token").
+		WithHeader("X-CSRF-Token", "token").
+		Post("/testing/csrf", nil)
+	s.Require().NoError(err)
+	resp.AssertStatus(http.StatusOK)
+
+	resp, err = s.Http(s.T()).
+		WithCookie("XSRF-TOKEN", "token").
+		WithHeader("X-CSRF-Token", "other").
+		Post("/testing/csrf", nil)
+	s.Require().NoError(err)
+	resp.AssertForbidden()
+}
+
+func (s *SecurityTestSuite) TestVerifyEmailPageSubmitsCsrfToken() {
+	view, err := template.ParseFiles("../../resources/views/email-verify.tmpl")
+	s.Require().NoError(err)
+
+	var page bytes.Buffer
+	s.Require().NoError(view.ExecuteTemplate(&page, "email-verify.tmpl", map[string]any{
+		"confirm":    true,
+		"csrf_token": "token",
+	}))
+	s.Contains(page.String(), `<form method="POST"`)
+	s.Contains(page.String(), `<input type="hidden" name="_token" value="token">`)
+}
+
+func (s *SecurityTestSuite) TestVerifyEmailRequiresCsrfToken() {
+	// Other suites may have used up the auth limit of the client
+	facades.Config().Add("rate_limit.enabled", false)
+	defer facades.Config().Add("rate_limit.enabled", true)
+
+	resp, err := s.Http(s.T()).Post("/auth/verify/token", nil)
+	s.Require().NoError(err)
+	resp.AssertForbidden()
+
+	body, err := resp.Json()
+	s.Require().NoError(err)
+	s.Equal("CSRF_TOKEN_MISMATCH", body["errors"])
+}
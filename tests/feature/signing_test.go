@@ -0,0 +1,100 @@
+package feature
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/signing"
+	"evote-be/tests"
+)
+
+type SigningTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestSigningTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningTestSuite))
+}
+
+// sign signs claims with the key as the service does
+func (s *SigningTestSuite) sign(algorithm string) (string, signing.JSONWebKey) {
+	key, err := signing.NewKey(algorithm)
+	s.Require().NoError(err)
+	s.NotContains(key.PrivateKey, "PRIVATE KEY", "the private key is stored encrypted")
+
+	signer, err := signing.Signer(key)
+	s.Require().NoError(err)
+
+	method := jwt.SigningMethod(jwt.SigningMethodRS256)
+	if algorithm == signing.EdDSA {
+		method = jwt.SigningMethodEdDSA
+	}
+	token := jwt.NewWithClaims(method, jwt.RegisteredClaims{
+		Subject:   "1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+	token.Header["kid"] = key.Kid
+	signed, err := token.SignedString(signer)
+	s.Require().NoError(err)
+
+	jwk, err := signing.PublicJWK(key)
+	s.Require().NoError(err)
+	s.Equal(key.Kid, jwk.Kid)
+	s.Equal("sig", jwk.Use)
+
+	return signed, jwk
+}
+
+func (s *SigningTestSuite) TestRS256TokenVerifiesWithPublishedKey() {
+	signed, jwk := s.sign(signing.RS256)
+	s.Equal("RSA", jwk.Kty)
+
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	s.Require().NoError(err)
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	s.Require().NoError(err)
+	public := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	token, err := jwt.Parse(signed, func(token *jwt.Token) (any, error) {
+		s.Equal(jwk.Kid, token.Header["kid"])
+		return public, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	s.Require().NoError(err)
+	s.True(token.Valid)
+}
+
+func (s *SigningTestSuite) TestEdDSATokenVerifiesWithPublishedKey() {
+	signed, jwk := s.sign(signing.EdDSA)
+	s.Equal("OKP", jwk.Kty)
+	s.Equal("Ed25519", jwk.Crv)
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	s.Require().NoError(err)
+
+	token, err := jwt.Parse(signed, func(token *jwt.Token) (any, error) {
+		return ed25519.PublicKey(x), nil
+	}, jwt.WithValidMethods([]string{"EdDSA"}))
+	s.Require().NoError(err)
+	s.True(token.Valid)
+}
+
+func (s *SigningTestSuite) TestUnsupportedAlgorithm() {
+	_, err := signing.NewKey("HS256")
+	s.ErrorIs(err, signing.ErrUnsupportedAlgorithm)
+}
+
+func (s *SigningTestSuite) TestTokenWithoutKeyIDIsLeftToTheSecret() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "1"}).SignedString([]byte("secret"))
+	s.Require().NoError(err)
+
+	_, err = signing.Parse("Bearer " + token)
+	s.ErrorIs(err, signing.ErrNoKeyID)
+}
@@ -0,0 +1,191 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	contractstesting "github.com/goravel/framework/contracts/testing"
+	"github.com/goravel/framework/facades"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/signing"
+	"evote-be/app/services/tokens"
+	"evote-be/tests"
+)
+
+// tokenTables are the tables sessions and their tokens are stored in, with
+// a user whose tokens were invalidated
+var tokenTables = []string{
+	`CREATE TABLE users (id integer PRIMARY KEY AUTOINCREMENT, tokens_valid_after datetime, created_at datetime, updated_at datetime,
+		deleted_at datetime)`,
+	`INSERT INTO users (id, tokens_valid_after) VALUES (1, '2025-05-01 12:00:00')`,
+	`CREATE TABLE signing_keys (id integer PRIMARY KEY AUTOINCREMENT, kid text, algorithm text, private_key text, public_key text,
+		retired_at datetime, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE user_sessions (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, family_id text, user_agent text, ip_address text,
+		last_seen_at datetime, expires_at datetime, revoked_at datetime, created_at datetime, updated_at datetime)`,
+	`CREATE TABLE refresh_tokens (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, family_id text, token text, access_token text,
+		access_expires_at datetime, expires_at datetime, used_at datetime, revoked_at datetime, created_at datetime, updated_at datetime)`,
+}
+
+type TokensTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
+
+func (s *TokensTestSuite) SetupSuite() {
+	s.UseSqlite(s.T(), tokenTables...)
+
+	// Issuing and rotating read the device from the request
+	facades.Route().Get("/testing/tokens/issue", func(ctx contractshttp.Context) contractshttp.Response {
+		pair, err := tokens.Issue(ctx, 1)
+		if err != nil {
+			return ctx.Response().Json(contractshttp.StatusInternalServerError, contractshttp.Json{"error": err.Error()})
+		}
+		return ctx.Response().Success().Json(pair)
+	})
+	facades.Route().Get("/testing/tokens/rotate", func(ctx contractshttp.Context) contractshttp.Response {
+		pair, err := tokens.Rotate(ctx, ctx.Request().Query("refresh_token"))
+		if err != nil {
+			return ctx.Response().Json(contractshttp.StatusUnauthorized, contractshttp.Json{"error": err.Error()})
+		}
+		return ctx.Response().Success().Json(pair)
+	})
+}
+
+// pair reads the token pair of a response of the testing routes
+func (s *TokensTestSuite) pair(resp contractstesting.TestResponse) tokens.Pair {
+	resp.AssertOk()
+	body, err := resp.Json()
+	s.Require().NoError(err)
+
+	access, _ := body["AccessToken"].(string)
+	refresh, _ := body["RefreshToken"].(string)
+	s.Require().NotEmpty(access)
+	s.Require().NotEmpty(refresh)
+
+	return tokens.Pair{AccessToken: access, RefreshToken: refresh}
+}
+
+func (s *TokensTestSuite) issue() tokens.Pair {
+	resp, err := s.Http(s.T()).Get("/testing/tokens/issue")
+	s.Require().NoError(err)
+
+	return s.pair(resp)
+}
+
+func (s *TokensTestSuite) rotate(refreshToken string) contractstesting.TestResponse {
+	resp, err := s.Http(s.T()).Get("/testing/tokens/rotate?refresh_token=" + refreshToken)
+	s.Require().NoError(err)
+
+	return resp
+}
+
+func (s *TokensTestSuite) TestGenerate() {
+	token, hash, err := tokens.Generate()
+	s.Require().NoError(err)
+	s.Len(token, 64)
+	s.Equal(tokens.Hash(token), hash)
+	s.NotEqual(token, hash)
+
+	other, _, err := tokens.Generate()
+	s.Require().NoError(err)
+	s.NotEqual(token, other)
+}
+
+func (s *TokensTestSuite) TestInvalidated() {
+	invalidatedAt := time.Date(2025, 5, 1, 12, 0, 0, 400_000_000, time.UTC)
+
+	s.False(tokens.Invalidated(invalidatedAt, time.Time{}))
+	s.True(tokens.Invalidated(time.Date(2025, 5, 1, 11, 59, 59, 0, time.UTC), invalidatedAt))
+	s.True(tokens.Invalidated(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC), invalidatedAt), "issued within the same second")
+	s.False(tokens.Invalidated(time.Date(2025, 5, 1, 12, 0, 1, 0, time.UTC), invalidatedAt))
+}
+
+func (s *TokensTestSuite) TestInvalidatedAtCachedAsJSON() {
+	tokens.Forget(1)
+	invalidatedAt, err := tokens.InvalidatedAt(1)
+	s.Require().NoError(err)
+	s.Equal(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC).Unix(), invalidatedAt.Unix())
+	s.IsType("", facades.Cache().Get("auth:tokens_valid_after:1"))
+
+	// An entry that can't be decoded is read again from the database
+	s.Require().NoError(facades.Cache().Put("auth:tokens_valid_after:1", "not json", time.Minute))
+	invalidatedAt, err = tokens.InvalidatedAt(1)
+	s.Require().NoError(err)
+	s.Equal(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC).Unix(), invalidatedAt.Unix())
+	tokens.Forget(1)
+}
+
+func (s *TokensTestSuite) TestSessionCachedAsJSON() {
+	pair := s.issue()
+	key := "auth:session:" + tokens.Hash(pair.AccessToken)
+
+	session, err := tokens.Session(pair.AccessToken)
+	s.Require().NoError(err)
+	s.NotZero(session.ID)
+	s.Equal(uint(1), session.UserID)
+	s.IsType("", facades.Cache().Get(key))
+
+	cached, err := tokens.Session(pair.AccessToken)
+	s.Require().NoError(err)
+	s.Equal(session.ID, cached.ID)
+	s.Equal(session.FamilyID, cached.FamilyID)
+
+	s.Require().NoError(facades.Cache().Put(key, "not json", time.Minute))
+	reread, err := tokens.Session(pair.AccessToken)
+	s.Require().NoError(err)
+	s.Equal(session.ID, reread.ID)
+}
+
+func (s *TokensTestSuite) TestAccessTokensIssuedTogetherDiffer() {
+	first := s.issue()
+	second := s.issue()
+	s.NotEqual(first.AccessToken, second.AccessToken)
+
+	firstClaims, err := signing.Parse(first.AccessToken)
+	s.Require().NoError(err)
+	secondClaims, err := signing.Parse(second.AccessToken)
+	s.Require().NoError(err)
+	s.NotEmpty(firstClaims.ID)
+	s.NotEqual(firstClaims.ID, secondClaims.ID)
+}
+
+func (s *TokensTestSuite) TestRotateReplacesThePair() {
+	first := s.issue()
+	second := s.pair(s.rotate(first.RefreshToken))
+
+	s.NotEqual(first.AccessToken, second.AccessToken)
+	s.NotEqual(first.RefreshToken, second.RefreshToken)
+	s.True(tokens.IsRevoked(first.AccessToken))
+	s.False(tokens.IsRevoked(second.AccessToken))
+
+	third := s.pair(s.rotate(second.RefreshToken))
+	s.True(tokens.IsRevoked(second.AccessToken))
+	s.False(tokens.IsRevoked(third.AccessToken))
+}
+
+func (s *TokensTestSuite) TestReusedRefreshTokenRevokesTheSession() {
+	first := s.issue()
+	second := s.pair(s.rotate(first.RefreshToken))
+
+	s.rotate(first.RefreshToken).AssertUnauthorized().AssertJson(map[string]any{"error": tokens.ErrRefreshTokenReused.Error()})
+	s.True(tokens.IsRevoked(second.AccessToken))
+	s.rotate(second.RefreshToken).AssertUnauthorized().AssertJson(map[string]any{"error": tokens.ErrInvalidRefreshToken.Error()})
+}
+
+func (s *TokensTestSuite) TestLogoutKeepsOtherSessions() {
+	first := s.issue()
+	second := s.issue()
+
+	s.Require().NoError(tokens.Logout(first.AccessToken, time.Now().Add(time.Hour)))
+	s.True(tokens.IsRevoked(first.AccessToken))
+	s.False(tokens.IsRevoked(second.AccessToken))
+
+	s.rotate(first.RefreshToken).AssertUnauthorized()
+	s.pair(s.rotate(second.RefreshToken))
+}
@@ -0,0 +1,79 @@
+package feature
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/services/twofactor"
+	"evote-be/tests"
+)
+
+// rfcSecret is the base32 encoded SHA1 key of the RFC 6238 test vectors
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type TwoFactorTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
+
+func (s *TwoFactorTestSuite) TestCode() {
+	for unix, expected := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := twofactor.Code(rfcSecret, twofactor.Step(time.Unix(unix, 0)))
+		s.Require().NoError(err)
+		s.Equal(expected, code)
+	}
+}
+
+func (s *TwoFactorTestSuite) TestMatch() {
+	now := time.Unix(1234567890, 0)
+	step := twofactor.Step(now)
+
+	// Codes of the neighbouring steps are accepted for clock drift
+	previous, _ := twofactor.Code(rfcSecret, step-1)
+	matched, ok := twofactor.Match(rfcSecret, previous, now, 0)
+	s.True(ok)
+	s.Equal(step-1, matched)
+
+	// Used steps cannot be replayed
+	code, _ := twofactor.Code(rfcSecret, step)
+	_, ok = twofactor.Match(rfcSecret, code, now, step)
+	s.False(ok)
+
+	old, _ := twofactor.Code(rfcSecret, step-2)
+	_, ok = twofactor.Match(rfcSecret, old, now, 0)
+	s.False(ok)
+
+	_, ok = twofactor.Match(rfcSecret, "12345", now, 0)
+	s.False(ok)
+}
+
+func (s *TwoFactorTestSuite) TestGenerateRecoveryCodes() {
+	codes, err := twofactor.GenerateRecoveryCodes(twofactor.RecoveryCodeCount)
+	s.Require().NoError(err)
+	s.Len(codes, twofactor.RecoveryCodeCount)
+
+	seen := map[string]bool{}
+	for _, code := range codes {
+		s.Regexp(`^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+		s.False(seen[code])
+		seen[code] = true
+	}
+}
+
+func (s *TwoFactorTestSuite) TestURI() {
+	uri := twofactor.URI("Evote", "user@example.com", rfcSecret)
+	s.Contains(uri, "otpauth://totp/Evote:user@example.com?")
+	s.Contains(uri, "secret="+rfcSecret)
+	s.Contains(uri, "issuer=Evote")
+}
@@ -0,0 +1,69 @@
+package feature
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/support/carbon"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/users"
+	"evote-be/tests"
+)
+
+type UsersTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestUsersTestSuite(t *testing.T) {
+	suite.Run(t, new(UsersTestSuite))
+}
+
+func (s *UsersTestSuite) TestCheck() {
+	now := time.Now()
+	user := models.User{Model: orm.Model{ID: 1}, EmailVerifiedAt: &now}
+	s.NoError(users.Check(user))
+
+	s.ErrorIs(users.Check(models.User{}), users.ErrNotFound)
+
+	banned := user
+	banned.BannedAt = &now
+	s.ErrorIs(users.Check(banned), users.ErrBanned)
+
+	unverified := user
+	unverified.EmailVerifiedAt = nil
+	s.ErrorIs(users.Check(unverified), users.ErrUnverified)
+}
+
+func (s *UsersTestSuite) TestCurrent() {
+	_, ok := users.Current(context.Background())
+	s.False(ok)
+
+	_, ok = users.Current(context.WithValue(context.Background(), "user", models.User{}))
+	s.False(ok)
+
+	user, ok := users.Current(context.WithValue(context.Background(), "user", models.User{Model: orm.Model{ID: 7}, Name: "Voter"}))
+	s.True(ok)
+	s.Equal(uint(7), user.ID)
+	s.Equal("Voter", user.Name)
+}
+
+func (s *UsersTestSuite) TestFindUsesCache() {
+	now := time.Now()
+	timestamps := orm.Timestamps{CreatedAt: carbon.NewDateTime(carbon.Now()), UpdatedAt: carbon.NewDateTime(carbon.Now())}
+	data, err := json.Marshal(models.User{Model: orm.Model{ID: 424242, Timestamps: timestamps}, Name: "Cached", BannedAt: &now})
+	s.Require().NoError(err)
+	s.Require().NoError(facades.Cache().Put("auth:user:424242", string(data), time.Minute))
+	defer users.Forget(424242)
+
+	user, err := users.Find(424242)
+	s.Require().NoError(err)
+	s.Equal("Cached", user.Name)
+	s.ErrorIs(users.Check(user), users.ErrBanned)
+}
@@ -0,0 +1,159 @@
+package feature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	contractshttp "github.com/goravel/framework/contracts/http"
+	"github.com/goravel/framework/database/orm"
+	"github.com/goravel/framework/facades"
+	"github.com/goravel/framework/support/carbon"
+	"github.com/stretchr/testify/suite"
+
+	"evote-be/app/models"
+	"evote-be/app/services/tokens"
+	"evote-be/app/services/webhook"
+	"evote-be/tests"
+)
+
+type WebhookTestSuite struct {
+	suite.Suite
+	tests.TestCase
+}
+
+func TestWebhookTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookTestSuite))
+}
+
+func (s *WebhookTestSuite) SetupSuite() {
+	facades.Route().Get("/testing/webhooks/issue", func(ctx contractshttp.Context) contractshttp.Response {
+		pair, err := tokens.Issue(ctx, 1)
+		if err != nil {
+			return ctx.Response().Json(contractshttp.StatusInternalServerError, contractshttp.Json{"error": err.Error()})
+		}
+		return ctx.Response().Success().Json(pair)
+	})
+}
+
+func (s *WebhookTestSuite) TestSign() {
+	body := []byte(`{"event":"vote.cast"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	s.Equal(expected, webhook.Sign("secret", 1700000000, body))
+	s.NotEqual(expected, webhook.Sign("other", 1700000000, body))
+}
+
+func (s *WebhookTestSuite) TestBackoff() {
+	s.Equal(30*time.Second, webhook.Backoff(1))
+	s.Equal(60*time.Second, webhook.Backoff(2))
+	s.Equal(240*time.Second, webhook.Backoff(4))
+	s.Equal(time.Hour, webhook.Backoff(20))
+}
+
+func (s *WebhookTestSuite) TestValidateURL() {
+	env := facades.Config().GetString("app.env")
+	defer facades.Config().Add("app.env", env)
+	facades.Config().Add("app.env", "production")
+
+	for _, url := range []string{
+		"https://127.0.0.1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.10/hook",
+		"https://[::1]/hook",
+		"https://0.0.0.0/hook",
+	} {
+		s.ErrorIs(webhook.ValidateURL(url), webhook.ErrBlockedAddress, url)
+	}
+
+	s.ErrorIs(webhook.ValidateURL("http://93.184.216.34/hook"), webhook.ErrInsecureURL)
+	s.NoError(webhook.ValidateURL("https://93.184.216.34/hook"))
+
+	facades.Config().Add("app.env", "local")
+	s.NoError(webhook.ValidateURL("http://93.184.216.34/hook"))
+}
+
+func (s *WebhookTestSuite) TestClientRefusesInternalAddresses() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := webhook.NewClient(time.Second).Get(server.URL)
+	s.ErrorIs(err, webhook.ErrBlockedAddress)
+}
+
+// webhookTables hold the webhooks around poll 1 of user 1: one registered on
+// the poll by collaborator 2, an account-wide one of the owner and an
+// account-wide one of the collaborator, which must not see the owner's polls.
+var webhookTables = []string{
+	`CREATE TABLE webhooks (id integer PRIMARY KEY AUTOINCREMENT, user_id integer, poll_id integer, url text, secret text,
+		events text, active numeric, created_at datetime, updated_at datetime, deleted_at datetime)`,
+	`CREATE TABLE webhook_deliveries (id integer PRIMARY KEY AUTOINCREMENT, webhook_id integer, event text, payload text,
+		status text, attempts integer, response_code integer, response_body text, error text, next_attempt_at datetime,
+		delivered_at datetime, created_at datetime, updated_at datetime)`,
+	`INSERT INTO webhooks (id, user_id, poll_id, url, secret, events, active) VALUES
+		(1, 2, 1, 'https://collaborator.example/poll', 'secret', 'vote.cast', true),
+		(2, 1, NULL, 'https://owner.example/all', 'secret', 'vote.cast', true),
+		(3, 2, NULL, 'https://collaborator.example/all', 'secret', 'vote.cast', true),
+		(4, 2, 2, 'https://collaborator.example/other', 'secret', 'vote.cast', true)`,
+}
+
+func (s *WebhookTestSuite) TestRecordCollaboratorWebhook() {
+	s.UseSqlite(s.T(), webhookTables...)
+
+	poll := models.Polls{Model: orm.Model{ID: 1}, UserID: 1}
+	ids, err := webhook.Record(poll, models.VoteCastEvent, map[string]any{"poll_id": 1})
+	s.Require().NoError(err)
+	s.Len(ids, 2)
+
+	var deliveries []models.WebhookDeliveries
+	s.Require().NoError(facades.Orm().Query().Where("id IN ?", ids).Order("webhook_id").Find(&deliveries))
+	s.Require().Len(deliveries, 2)
+	s.Equal(uint(1), deliveries[0].WebhookID)
+	s.Equal(uint(2), deliveries[1].WebhookID)
+	s.Equal(models.DeliveryPending, deliveries[0].Status)
+	s.Contains(deliveries[0].Payload, `"event":"vote.cast"`)
+}
+
+func (s *WebhookTestSuite) TestDeliveriesWithoutLimit() {
+	schema := append(append([]string{}, tokenTables...), webhookTables...)
+	for range 12 {
+		schema = append(schema, `INSERT INTO webhook_deliveries (webhook_id, event, status) VALUES (2, 'vote.cast', 'Delivered')`)
+	}
+	s.UseSqlite(s.T(), schema...)
+
+	// The owner of the webhook may manage webhooks
+	timestamps := orm.Timestamps{CreatedAt: carbon.NewDateTime(carbon.Now()), UpdatedAt: carbon.NewDateTime(carbon.Now())}
+	now := time.Now()
+	user, err := json.Marshal(models.User{Model: orm.Model{ID: 1, Timestamps: timestamps}, Name: "Owner", EmailVerifiedAt: &now})
+	s.Require().NoError(err)
+	s.Require().NoError(facades.Cache().Put("auth:user:1", string(user), time.Minute))
+	s.Require().NoError(facades.Cache().Put("auth:permissions:1", `{"webhook.manage":true}`, time.Minute))
+	defer facades.Cache().Forget("auth:user:1")
+	defer facades.Cache().Forget("auth:permissions:1")
+
+	resp, err := s.Http(s.T()).Get("/testing/webhooks/issue")
+	s.Require().NoError(err)
+	body, err := resp.AssertOk().Json()
+	s.Require().NoError(err)
+
+	resp, err = s.Http(s.T()).WithHeader("Authorization", "Bearer "+body["AccessToken"].(string)).Get("/webhooks/2/deliveries?limit=0")
+	s.Require().NoError(err)
+	body, err = resp.AssertOk().Json()
+	s.Require().NoError(err)
+	s.Len(body["data"], 10)
+	meta := body["meta"].(map[string]any)
+	s.Equal(float64(10), meta["per_page"])
+	s.Equal(float64(2), meta["last_page"])
+	s.Equal(float64(1), meta["curr_page"])
+}